@@ -1,15 +1,52 @@
 package sqlbuilder
 
+import (
+	"reflect"
+)
+
+// AlterTableCapability represents operations which a dialect accepts in ALTER TABLE statement.
+type AlterTableCapability int
+
+const (
+	// several actions in one ALTER TABLE statement.  RENAME TO and RENAME COLUMN are excluded.
+	AlterTableMultipleActions AlterTableCapability = 1 << iota
+	// RENAME TO and RENAME COLUMN with other actions in one ALTER TABLE statement.
+	AlterTableCombineRename
+	// ADD COLUMN (or ADD without COLUMN keyword)
+	AlterTableColumnKeyword
+	// CHANGE COLUMN old new type option
+	AlterTableChangeColumn
+	// ALTER COLUMN ... TYPE / SET DEFAULT / DROP DEFAULT / SET NOT NULL / DROP NOT NULL
+	AlterTableAlterColumn
+	// ... FIRST / ... AFTER column
+	AlterTableColumnPosition
+	// RENAME COLUMN old TO new
+	AlterTableRenameColumn
+	// RENAME TO new
+	AlterTableRenameTable
+	// ADD CONSTRAINT ... / ADD FOREIGN KEY ...
+	AlterTableAddConstraint
+	// RENAME TO schema.new.  The new name is qualified with the schema of the table.
+	AlterTableRenameQualified
+)
+
+// AlterTableStatement represents a "ALTER TABLE" statement.
+// Actions which the dialect can not write in one statement are split into several statements separated by the query suffix.
 type AlterTableStatement struct {
 	table          *table
 	rename_to      string
 	add_columns    []*alterTableAddColumn
 	drop_columns   []Column
 	change_columns []*alterTableChangeColumn
+	rename_columns []*alterTableRenameColumn
+	add_fks        []ForeignKey
+
+	dialect Dialect
 
 	err error
 }
 
+// AlterTable returns new "ALTER TABLE" statement. The tbl is Table object to alter.
 func AlterTable(tbl Table) *AlterTableStatement {
 	if tbl == nil {
 		return &AlterTableStatement{
@@ -135,61 +172,155 @@ func (b *AlterTableStatement) ChangeColumnFirst(old_column Column, new_column Co
 	return b
 }
 
+// RenameColumn adds "RENAME COLUMN" clause.  The col is renamed to the name.
+func (b *AlterTableStatement) RenameColumn(col Column, name string) *AlterTableStatement {
+	if b.err != nil {
+		return b
+	}
+
+	b.rename_columns = append(b.rename_columns, &alterTableRenameColumn{
+		table:  b.table,
+		column: col,
+		name:   name,
+	})
+	return b
+}
+
+// AddForeignKey adds "ADD CONSTRAINT ... FOREIGN KEY" clause.  Constraint name is omitted if fk.Name is empty.
+func (b *AlterTableStatement) AddForeignKey(fk ForeignKey) *AlterTableStatement {
+	if b.err != nil {
+		return b
+	}
+
+	b.add_fks = append(b.add_fks, fk)
+	return b
+}
+
+// ToSql generates query string, placeholder arguments, and returns err on errors.
+// Returns an error if the dialect needs several statements, use ToSqls() for it.
 func (b *AlterTableStatement) ToSql() (query string, args []interface{}, err error) {
-	bldr := newBuilder()
-	defer func() {
-		query, args, err = bldr.Query(), bldr.Args(), bldr.Err()
-	}()
+	return b.ToSqlWith(b.dialect)
+}
+
+// ToSqlWith generates query string, placeholder arguments with the d, and returns err on errors.
+// The default dialect is used if the d is nil.
+func (b *AlterTableStatement) ToSqlWith(d Dialect) (query string, args []interface{}, err error) {
+	queries, args_list, err := b.ToSqlsWith(d)
+	if err != nil {
+		return "", []interface{}{}, err
+	}
+	if len(queries) > 1 {
+		return "", []interface{}{}, newError("dialect needs %d ALTER TABLE statements, use ToSqls().", len(queries))
+	}
+	return queries[0], args_list[0], nil
+}
+
+// ToSqls generates query strings and placeholder arguments for each statement, and returns err on errors.
+// Actions which the dialect can not write in one statement are split into several statements.
+// Execute them in order, they are not executed atomically on most databases.
+func (b *AlterTableStatement) ToSqls() (queries []string, args [][]interface{}, err error) {
+	return b.ToSqlsWith(b.dialect)
+}
+
+// ToSqlsWith generates query strings and placeholder arguments with the d, and returns err on errors.
+// The default dialect is used if the d is nil.
+func (b *AlterTableStatement) ToSqlsWith(d Dialect) (queries []string, args [][]interface{}, err error) {
 	if b.err != nil {
-		bldr.SetError(b.err)
-		return
+		return nil, nil, b.err
+	}
+	if d == nil {
+		d = dialect()
 	}
 
-	bldr.Append("ALTER TABLE ")
-	bldr.AppendItem(b.table)
-	bldr.Append(" ")
+	statements, err := b.statements(d)
+	if err != nil {
+		return nil, nil, err
+	}
+	queries = make([]string, 0, len(statements))
+	args = make([][]interface{}, 0, len(statements))
+	for _, statement := range statements {
+		bldr := newBuilderWith(d)
+		bldr.Append("ALTER TABLE ")
+		bldr.AppendItem(b.table)
+		bldr.Append(" ")
+		bldr.AppendItems(statement, ", ")
+		if err := bldr.Err(); err != nil {
+			return nil, nil, err
+		}
+		queries = append(queries, bldr.Query())
+		args = append(args, bldr.Args())
+	}
+	return queries, args, nil
+}
 
-	first := true
+// statements returns actions grouped into statements which the d can write.
+func (b *AlterTableStatement) statements(d Dialect) ([][]serializable, error) {
+	caps := d.AlterTableCapabilities()
+	actions := make([]serializable, 0)
+	renames := make([]serializable, 0)
 	for _, add_column := range b.add_columns {
-		if first {
-			first = false
-		} else {
-			bldr.Append(", ")
+		actions = append(actions, add_column)
+		// foreign key of the new column.  the column does not exist in the table yet.
+		for _, constraint := range columnConstraints(nil, add_column.column) {
+			actions = append(actions, alterTableAddConstraint{constraint})
 		}
-		bldr.AppendItem(add_column)
 	}
 	for _, change_column := range b.change_columns {
-		if first {
-			first = false
-		} else {
-			bldr.Append(", ")
+		switch {
+		case caps&AlterTableChangeColumn != 0:
+			actions = append(actions, change_column)
+		case caps&AlterTableAlterColumn != 0:
+			alters, rename, e := change_column.toAlterColumns()
+			if e != nil {
+				return nil, e
+			}
+			actions = append(actions, alters...)
+			if rename != nil {
+				renames = append(renames, rename)
+			}
+		default:
+			return nil, newError("dialect does not support changing column in ALTER TABLE statement.")
 		}
-		bldr.AppendItem(change_column)
 	}
 	for _, drop_column := range b.drop_columns {
-		if first {
-			first = false
-		} else {
-			bldr.Append(", ")
-		}
-		bldr.Append("DROP COLUMN ")
-		if colname := drop_column.column_name(); len(colname) != 0 {
-			bldr.Append(dialect().QuoteField(colname))
-		} else {
-			bldr.AppendItem(drop_column)
-		}
+		actions = append(actions, alterTableDropColumn{drop_column})
+	}
+	for i := range b.add_fks {
+		actions = append(actions, alterTableAddConstraint{foreignKeyConstraint{
+			table: b.table,
+			fk:    &b.add_fks[i],
+		}})
+	}
+	for _, rename_column := range b.rename_columns {
+		renames = append(renames, rename_column)
 	}
 	if len(b.rename_to) != 0 {
-		if first {
-			first = false
-		} else {
-			bldr.Append(", ")
-		}
-		bldr.Append("RENAME TO ")
-		bldr.Append(dialect().QuoteField(b.rename_to))
+		renames = append(renames, alterTableRenameTo{b.table, b.rename_to})
 	}
 
-	return "", nil, nil
+	// group actions into statements.
+	statements := make([][]serializable, 0)
+	switch {
+	case caps&AlterTableCombineRename != 0 && caps&AlterTableMultipleActions != 0:
+		if all := append(actions, renames...); len(all) != 0 {
+			statements = append(statements, all)
+		}
+	case caps&AlterTableMultipleActions != 0:
+		if len(actions) != 0 {
+			statements = append(statements, actions)
+		}
+		for _, rename := range renames {
+			statements = append(statements, []serializable{rename})
+		}
+	default:
+		for _, action := range append(actions, renames...) {
+			statements = append(statements, []serializable{action})
+		}
+	}
+	if len(statements) == 0 {
+		return nil, newError("ALTER TABLE statement needs one or more actions.")
+	}
+	return statements, nil
 }
 
 func (b *AlterTableStatement) ApplyToTable() error {
@@ -211,6 +342,15 @@ func (b *AlterTableStatement) ApplyToTable() error {
 			return err
 		}
 	}
+	for _, rename_column := range b.rename_columns {
+		err := rename_column.applyToTable()
+		if err != nil {
+			return err
+		}
+	}
+	if len(b.add_fks) != 0 {
+		b.table.option.ForeignKeys = append(b.table.option.ForeignKeys, b.add_fks...)
+	}
 	if len(b.rename_to) != 0 {
 		b.table.SetName(b.rename_to)
 	}
@@ -225,11 +365,15 @@ type alterTableAddColumn struct {
 }
 
 func (b *alterTableAddColumn) serialize(bldr *builder) {
-	bldr.Append("ADD COLUMN ")
+	if bldr.dialect.AlterTableCapabilities()&AlterTableColumnKeyword != 0 {
+		bldr.Append("ADD COLUMN ")
+	} else {
+		bldr.Append("ADD ")
+	}
 	bldr.AppendItem(b.column)
 
 	// SQL data name
-	typ, err := dialect().ColumnTypeToString(b.column)
+	typ, err := bldr.dialect.ColumnTypeToString(b.column)
 	if err != nil {
 		bldr.SetError(err)
 	} else if len(typ) == 0 {
@@ -239,7 +383,7 @@ func (b *alterTableAddColumn) serialize(bldr *builder) {
 		bldr.Append(typ)
 	}
 
-	opt, err := dialect().ColumnOptionToString(b.column.Option())
+	opt, err := bldr.dialect.ColumnOptionToString(b.column.Option())
 	if err != nil {
 		bldr.SetError(err)
 	} else if len(opt) != 0 {
@@ -247,16 +391,7 @@ func (b *alterTableAddColumn) serialize(bldr *builder) {
 		bldr.Append(opt)
 	}
 
-	if b.first {
-		bldr.Append(" FIRST")
-	} else if b.after != nil {
-		bldr.Append(" AFTER ")
-		if colname := b.after.column_name(); len(colname) != 0 {
-			bldr.Append(dialect().QuoteField(colname))
-		} else {
-			bldr.AppendItem(b.after)
-		}
-	}
+	appendColumnPosition(bldr, b.first, b.after)
 }
 
 func (b *alterTableAddColumn) applyToTable() error {
@@ -279,15 +414,11 @@ type alterTableChangeColumn struct {
 
 func (b *alterTableChangeColumn) serialize(bldr *builder) {
 	bldr.Append("CHANGE COLUMN ")
-	if colname := b.old_column.column_name(); len(colname) != 0 {
-		bldr.Append(dialect().QuoteField(colname))
-	} else {
-		bldr.AppendItem(b.old_column)
-	}
+	appendColumnName(bldr, b.old_column)
 	bldr.Append(" ")
 	bldr.AppendItem(b.new_column)
 
-	typ, err := dialect().ColumnTypeToString(b.new_column)
+	typ, err := bldr.dialect.ColumnTypeToString(b.new_column)
 	if err != nil {
 		bldr.SetError(err)
 	} else if len(typ) == 0 {
@@ -297,7 +428,7 @@ func (b *alterTableChangeColumn) serialize(bldr *builder) {
 		bldr.Append(typ)
 	}
 
-	opt, err := dialect().ColumnOptionToString(b.new_column.Option())
+	opt, err := bldr.dialect.ColumnOptionToString(b.new_column.Option())
 	if err != nil {
 		bldr.SetError(err)
 	} else if len(opt) != 0 {
@@ -305,16 +436,48 @@ func (b *alterTableChangeColumn) serialize(bldr *builder) {
 		bldr.Append(opt)
 	}
 
-	if b.first {
-		bldr.Append(" FIRST")
-	} else if b.after != nil {
-		bldr.Append(" AFTER ")
-		if colname := b.after.column_name(); len(colname) != 0 {
-			bldr.Append(dialect().QuoteField(colname))
-		} else {
-			bldr.AppendItem(b.after)
+	appendColumnPosition(bldr, b.first, b.after)
+}
+
+// toAlterColumns returns ALTER COLUMN actions and RENAME COLUMN action which have same effect as CHANGE COLUMN.
+// rename is nil if the name of the column is not changed.
+func (b *alterTableChangeColumn) toAlterColumns() (alters []serializable, rename serializable, err error) {
+	if ecol, ok := b.old_column.(*errorColumn); ok {
+		return nil, nil, ecol.err
+	}
+	if b.first || b.after != nil {
+		return nil, nil, newError("dialect does not support FIRST and AFTER clause in ALTER TABLE statement.")
+	}
+	old_cc := b.old_column.config()
+	old_opt, new_opt := old_cc.Option(), b.new_column.Option()
+	if old_opt.PrimaryKey != new_opt.PrimaryKey || old_opt.Unique != new_opt.Unique ||
+		old_opt.AutoIncrement != new_opt.AutoIncrement || !equalForeignKey(old_opt.ForeignKey, new_opt.ForeignKey) {
+		return nil, nil, newError("dialect can not change constraints of column in ALTER TABLE statement.")
+	}
+	// type of auto increment column(ex: SERIAL) can not be written in ALTER COLUMN, and its default is the sequence.
+	if new_opt.AutoIncrement {
+		return nil, nil, newError("dialect can not change auto increment column in ALTER TABLE statement.")
+	}
+
+	// write only changed properties.
+	alters = make([]serializable, 0, 3)
+	if old_cc.Type() != b.new_column.Type() || old_opt.Size != new_opt.Size || old_opt.SqlType != new_opt.SqlType {
+		alters = append(alters, &alterTableAlterColumn{b.old_column, b.new_column, alter_column_type})
+	}
+	if !reflect.DeepEqual(old_opt.Default, new_opt.Default) {
+		alters = append(alters, &alterTableAlterColumn{b.old_column, b.new_column, alter_column_default})
+	}
+	if old_opt.NotNull != new_opt.NotNull {
+		alters = append(alters, &alterTableAlterColumn{b.old_column, b.new_column, alter_column_not_null})
+	}
+	if b.old_column.column_name() != b.new_column.Name() {
+		rename = &alterTableRenameColumn{
+			table:  b.table,
+			column: b.old_column,
+			name:   b.new_column.Name(),
 		}
 	}
+	return alters, rename, nil
 }
 
 func (b *alterTableChangeColumn) applyToTable() error {
@@ -326,3 +489,145 @@ func (b *alterTableChangeColumn) applyToTable() error {
 	}
 	return b.table.ChangeColumn(b.old_column, b.new_column)
 }
+
+type alterColumnAction int
+
+const (
+	alter_column_type alterColumnAction = iota
+	alter_column_default
+	alter_column_not_null
+)
+
+// alterTableAlterColumn is an "ALTER COLUMN" action which changes one property of the column to new_column's one.
+type alterTableAlterColumn struct {
+	column     Column
+	new_column ColumnConfig
+	action     alterColumnAction
+}
+
+func (b *alterTableAlterColumn) serialize(bldr *builder) {
+	bldr.Append("ALTER COLUMN ")
+	appendColumnName(bldr, b.column)
+
+	opt := b.new_column.Option()
+	switch b.action {
+	case alter_column_type:
+		typ, err := bldr.dialect.ColumnTypeToString(b.new_column)
+		if err != nil {
+			bldr.SetError(err)
+		} else if len(typ) == 0 {
+			bldr.SetError(newError("column type is required.(maybe, a bug is in implements of dialect.)"))
+		} else {
+			bldr.Append(" TYPE " + typ)
+		}
+	case alter_column_default:
+		if opt.Default == nil {
+			bldr.Append(" DROP DEFAULT")
+		} else {
+			bldr.Append(" SET DEFAULT ")
+			bldr.constraint = true
+			bldr.AppendItem(toLiteral(opt.Default))
+			bldr.constraint = false
+		}
+	case alter_column_not_null:
+		if opt.NotNull {
+			bldr.Append(" SET NOT NULL")
+		} else {
+			bldr.Append(" DROP NOT NULL")
+		}
+	}
+}
+
+type alterTableRenameColumn struct {
+	table  *table
+	column Column
+	name   string
+}
+
+func (b *alterTableRenameColumn) serialize(bldr *builder) {
+	if bldr.dialect.AlterTableCapabilities()&AlterTableRenameColumn == 0 {
+		bldr.SetError(newError("dialect does not support RENAME COLUMN clause in ALTER TABLE statement."))
+		return
+	}
+	bldr.Append("RENAME COLUMN ")
+	appendColumnName(bldr, b.column)
+	bldr.Append(" TO " + bldr.QuoteIdent(b.name))
+}
+
+func (b *alterTableRenameColumn) applyToTable() error {
+	cc := b.column.config()
+	if cc == nil {
+		return newError("column not found.")
+	}
+	return b.table.ChangeColumn(b.column, &columnConfigImpl{
+		name: b.name,
+		typ:  cc.Type(),
+		opt:  cc.Option(),
+	})
+}
+
+type alterTableRenameTo struct {
+	table Table
+	name  string
+}
+
+func (b alterTableRenameTo) serialize(bldr *builder) {
+	if bldr.dialect.AlterTableCapabilities()&AlterTableRenameTable == 0 {
+		bldr.SetError(newError("dialect does not support RENAME TO clause in ALTER TABLE statement."))
+		return
+	}
+	bldr.Append("RENAME TO ")
+	// some databases move the table to the current schema if the new name is not qualified.
+	if opt := b.table.Option(); bldr.dialect.AlterTableCapabilities()&AlterTableRenameQualified != 0 && opt != nil && len(opt.Schema) != 0 {
+		bldr.Append(bldr.QuoteIdent(opt.Schema) + ".")
+	}
+	bldr.Append(bldr.QuoteIdent(b.name))
+}
+
+type alterTableDropColumn struct {
+	column Column
+}
+
+func (b alterTableDropColumn) serialize(bldr *builder) {
+	bldr.Append("DROP COLUMN ")
+	appendColumnName(bldr, b.column)
+}
+
+type alterTableAddConstraint struct {
+	constraint serializable
+}
+
+func (b alterTableAddConstraint) serialize(bldr *builder) {
+	if bldr.dialect.AlterTableCapabilities()&AlterTableAddConstraint == 0 {
+		bldr.SetError(newError("dialect does not support adding constraint in ALTER TABLE statement."))
+		return
+	}
+	bldr.Append("ADD ")
+	bldr.AppendItem(b.constraint)
+}
+
+// appendColumnName appends unqualified name of the col.
+func appendColumnName(bldr *builder, col Column) {
+	if colname := col.column_name(); len(colname) != 0 {
+		bldr.Append(bldr.QuoteIdent(colname))
+	} else {
+		bldr.AppendItem(col)
+	}
+}
+
+// appendColumnPosition appends FIRST or AFTER clause if it is needed.
+func appendColumnPosition(bldr *builder, first bool, after Column) {
+	if !first && after == nil {
+		return
+	}
+	if bldr.dialect.AlterTableCapabilities()&AlterTableColumnPosition == 0 {
+		bldr.SetError(newError("dialect does not support FIRST and AFTER clause in ALTER TABLE statement."))
+		return
+	}
+	if first {
+		bldr.Append(" FIRST")
+	} else {
+		bldr.Append(" AFTER ")
+		appendColumnName(bldr, after)
+	}
+}
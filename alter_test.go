@@ -97,6 +97,21 @@ func TestAlterTable(t *testing.T) {
 		query:  ``,
 		args:   []interface{}{},
 		errmsg: "sqlbuilder: column TABLE_A.invalid was not found.",
+	}, {
+		stmt:   AlterTable(table1).RenameColumn(table1.C("test1"), "test1a").DropColumn(table1.C("test2")),
+		query:  `ALTER TABLE "TABLE_A" DROP COLUMN "test2", RENAME COLUMN "test1" TO "test1a";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   AlterTable(table1).RenameColumn(table1.C("invalid"), "test1a"),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column TABLE_A.invalid was not found.",
+	}, {
+		stmt:   AlterTable(table1),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: ALTER TABLE statement needs one or more actions.",
 	}, {
 		stmt:   AlterTable(nil).DropColumn(table1.C("invalid")),
 		query:  ``,
@@ -142,6 +157,13 @@ func TestAlterTableApplyToTable(t *testing.T) {
 		},
 		expect_columns: []string{"id", "test2", "test1a"},
 		expect_name:    "TABLE_A",
+	}, {
+		stmt: func(t Table) *AlterTableStatement {
+			return AlterTable(t).
+				RenameColumn(t.C("test1"), "test1a")
+		},
+		expect_columns: []string{"id", "test1a", "test2"},
+		expect_name:    "TABLE_A",
 	}}
 
 	for num, c := range cases {
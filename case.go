@@ -0,0 +1,198 @@
+package sqlbuilder
+
+// CaseExpression represents a "CASE WHEN ... THEN ... ELSE ... END" expression.  This can be use in the same way as Column.
+type CaseExpression interface {
+	Column
+
+	// When adds "WHEN cond THEN then" clause.  Type for then is Column, SqlFunc or literal value.
+	When(cond Condition, then interface{}) CaseExpression
+
+	// Else sets "ELSE val" clause.  Type for val is Column, SqlFunc or literal value.
+	Else(val interface{}) CaseExpression
+
+	columns() []Column
+}
+
+type caseWhen struct {
+	cond Condition
+	then serializable
+}
+
+type caseImpl struct {
+	whens   []caseWhen
+	els     serializable
+	hasElse bool
+}
+
+// Case returns new CASE expression.  Add WHEN clauses with When().
+// Values in the CASE are bound as placeholders every time it is written, so the same CASE in SELECT and
+// GROUP BY are different expressions for numbered placeholders(ex: $1).  Use an alias in GROUP BY instead.
+func Case() CaseExpression {
+	return &caseImpl{
+		whens: make([]caseWhen, 0),
+	}
+}
+
+func (m *caseImpl) When(cond Condition, then interface{}) CaseExpression {
+	m.whens = append(m.whens, caseWhen{
+		cond: cond,
+		then: toOperand(then),
+	})
+	return m
+}
+
+func (m *caseImpl) Else(val interface{}) CaseExpression {
+	m.els = toOperand(val)
+	m.hasElse = true
+	return m
+}
+
+// resultType returns ColumnType of result values.
+func (m *caseImpl) resultType() (ColumnType, error) {
+	results := make([]serializable, 0, len(m.whens)+1)
+	for _, w := range m.whens {
+		results = append(results, w.then)
+	}
+	if m.hasElse {
+		results = append(results, m.els)
+	}
+
+	typ := ColumnTypeAny
+	for _, r := range results {
+		if lit, ok := r.(literal); ok && lit.IsNil() {
+			continue
+		}
+		t := operandType(r)
+		switch {
+		case t == ColumnTypeAny || t == typ:
+		case typ == ColumnTypeAny:
+			typ = t
+		case (typ == ColumnTypeInt && t == ColumnTypeFloat) || (typ == ColumnTypeFloat && t == ColumnTypeInt):
+			typ = ColumnTypeFloat
+		default:
+			return ColumnTypeAny, newError("CASE needs same type for THEN and ELSE values, but got %s and %s.", typ.String(), t.String())
+		}
+	}
+	return typ, nil
+}
+
+func (m *caseImpl) As(alias string) Column {
+	return &aliasColumn{
+		column: m,
+		alias:  alias,
+	}
+}
+
+func (m *caseImpl) column_name() string {
+	return ""
+}
+
+func (m *caseImpl) config() ColumnConfig {
+	typ, _ := m.resultType()
+	return newColumnConfigImpl("", typ, nil)
+}
+
+func (m *caseImpl) acceptType(val interface{}) bool {
+	typ, _ := m.resultType()
+	return acceptLiteralType(typ, false, val)
+}
+
+func (m *caseImpl) serialize(bldr *builder) {
+	if len(m.whens) == 0 {
+		bldr.SetError(newError("CASE needs one or more WHEN clauses."))
+		return
+	}
+	if _, err := m.resultType(); err != nil {
+		bldr.SetError(err)
+		return
+	}
+
+	bldr.Append("CASE")
+	for _, w := range m.whens {
+		if w.cond == nil {
+			bldr.SetError(newError("condition of WHEN is nil."))
+			return
+		}
+		bldr.Append(" WHEN ")
+		bldr.AppendItem(w.cond)
+		bldr.Append(" THEN ")
+		bldr.AppendItem(w.then)
+	}
+	if m.hasElse {
+		bldr.Append(" ELSE ")
+		bldr.AppendItem(m.els)
+	}
+	bldr.Append(" END")
+}
+
+func (m *caseImpl) columns() []Column {
+	list := make([]Column, 0)
+	for _, w := range m.whens {
+		if w.cond != nil {
+			list = append(list, w.cond.columns()...)
+		}
+		if col, ok := w.then.(Column); ok {
+			list = append(list, col)
+		}
+	}
+	if col, ok := m.els.(Column); ok {
+		list = append(list, col)
+	}
+	return list
+}
+
+func (left *caseImpl) Eq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "=")
+}
+
+func (left *caseImpl) NotEq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "<>")
+}
+
+func (left *caseImpl) Gt(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, ">")
+}
+
+func (left *caseImpl) GtEq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, ">=")
+}
+
+func (left *caseImpl) Lt(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "<")
+}
+
+func (left *caseImpl) LtEq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "<=")
+}
+
+func (left *caseImpl) Like(right string) Condition {
+	return newBinaryOperationCondition(left, right, " LIKE ")
+}
+
+func (left *caseImpl) NotLike(right string) Condition {
+	return newBinaryOperationCondition(left, right, " NOT LIKE ")
+}
+
+func (left *caseImpl) Between(lower, higher interface{}) Condition {
+	return newBetweenCondition(left, lower, higher)
+}
+
+func (left *caseImpl) NotBetween(lower, higher interface{}) Condition {
+	return newNotBetweenCondition(left, lower, higher)
+}
+
+func (left *caseImpl) In(vals ...interface{}) Condition {
+	return newInCondition(left, vals...)
+}
+
+func (left *caseImpl) NotIn(vals ...interface{}) Condition {
+	return newNotInCondition(left, vals...)
+}
+
+func (left *caseImpl) IsNull() Condition {
+	return newNullCondition(left, false)
+}
+
+func (left *caseImpl) IsNotNull() Condition {
+	return newNullCondition(left, true)
+}
@@ -0,0 +1,154 @@
+package sqlbuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCaseImplements(t *testing.T) {
+	fnImplColumn := func(i interface{}) bool {
+		return reflect.TypeOf(i).Implements(reflect.TypeOf(new(Column)).Elem())
+	}
+	if !fnImplColumn(&caseImpl{}) {
+		t.Errorf("fail")
+	}
+}
+
+func TestCase(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("age", nil),
+		StringColumn("status", nil),
+		IntColumn("score", nil),
+	)
+	table2 := NewTable(
+		"TABLE_B",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+	)
+
+	label := Case().
+		When(table1.C("age").Lt(20), "child").
+		When(table1.C("age").Lt(65), "adult").
+		Else("senior")
+
+	var cases = []statementTestCase{{
+		stmt: Select(table1).
+			Columns(table1.C("id"), label.As("label")),
+		query: `SELECT "TABLE_A"."id", CASE WHEN "TABLE_A"."age"<? THEN ? WHEN "TABLE_A"."age"<? THEN ? ELSE ? END AS "label" ` +
+			`FROM "TABLE_A";`,
+		args:   []interface{}{int64(20), "child", int64(65), "adult", "senior"},
+		errmsg: "",
+	}, {
+		stmt: Select(table1).
+			Columns(Func("sum", Case().When(table1.C("status").Eq("active"), 1).Else(0))).
+			GroupBy(label).
+			OrderBy(false, label),
+		query: `SELECT sum(CASE WHEN "TABLE_A"."status"=? THEN ? ELSE ? END) FROM "TABLE_A" ` +
+			`GROUP BY CASE WHEN "TABLE_A"."age"<? THEN ? WHEN "TABLE_A"."age"<? THEN ? ELSE ? END ` +
+			`ORDER BY CASE WHEN "TABLE_A"."age"<? THEN ? WHEN "TABLE_A"."age"<? THEN ? ELSE ? END ASC;`,
+		args: []interface{}{"active", int64(1), int64(0),
+			int64(20), "child", int64(65), "adult", "senior",
+			int64(20), "child", int64(65), "adult", "senior"},
+		errmsg: "",
+	}, {
+		// values in CASE are bound every time, so refer the CASE in SELECT by its alias.
+		stmt: Select(table1).
+			Columns(label.As("label"), Count(Star)).
+			GroupBy(label.As("label")),
+		query: `SELECT CASE WHEN "TABLE_A"."age"<? THEN ? WHEN "TABLE_A"."age"<? THEN ? ELSE ? END AS "label", COUNT(*) ` +
+			`FROM "TABLE_A" GROUP BY "label";`,
+		args:   []interface{}{int64(20), "child", int64(65), "adult", "senior"},
+		errmsg: "",
+	}, {
+		stmt: Update(table1).
+			Set(table1.C("score"), Case().When(table1.C("age").Gt(20), table1.C("age")).Else(nil)).
+			Where(table1.C("id").Eq(1)),
+		query:  `UPDATE "TABLE_A" SET "score"=CASE WHEN "TABLE_A"."age">? THEN "TABLE_A"."age" ELSE ? END WHERE "TABLE_A"."id"=?;`,
+		args:   []interface{}{int64(20), nil, int64(1)},
+		errmsg: "",
+	}, {
+		stmt: Select(table1).
+			Where(label.Eq("adult")),
+		query:  `SELECT * FROM "TABLE_A" WHERE CASE WHEN "TABLE_A"."age"<? THEN ? WHEN "TABLE_A"."age"<? THEN ? ELSE ? END=?;`,
+		args:   []interface{}{int64(20), "child", int64(65), "adult", "senior", "adult"},
+		errmsg: "",
+	}, {
+		stmt: Update(table1).
+			Set(table1.C("score"), label),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: int column not accept string column.",
+	}, {
+		stmt: Select(table1).
+			Columns(Case().When(table1.C("age").Lt(20), "child").Else(1)),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: CASE needs same type for THEN and ELSE values, but got string and int.",
+	}, {
+		stmt: Select(table1).
+			Columns(Case().Else(1)),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: CASE needs one or more WHEN clauses.",
+	}, {
+		stmt: Select(table1).
+			Columns(Case().When(table2.C("id").Eq(1), 1)),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column not found in FROM.",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
+
+func TestCaseNumberedBindVar(t *testing.T) {
+	SetDialect(NumberedTestDialect{})
+	defer SetDialect(TestDialect{})
+
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("age", nil),
+	)
+	label := Case().When(table1.C("age").Lt(20), "child").Else("adult")
+
+	var cases = []statementTestCase{{
+		// the same CASE is written with different placeholders, databases treat these as different expressions.
+		stmt: Select(table1).
+			Columns(label, Count(Star)).
+			GroupBy(label),
+		query: `SELECT CASE WHEN "TABLE_A"."age"<$1 THEN $2 ELSE $3 END, COUNT(*) ` +
+			`FROM "TABLE_A" GROUP BY CASE WHEN "TABLE_A"."age"<$4 THEN $5 ELSE $6 END;`,
+		args:   []interface{}{int64(20), "child", "adult", int64(20), "child", "adult"},
+		errmsg: "",
+	}, {
+		stmt: Select(table1).
+			Columns(label.As("label"), Count(Star)).
+			GroupBy(label.As("label")),
+		query:  `SELECT CASE WHEN "TABLE_A"."age"<$1 THEN $2 ELSE $3 END AS "label", COUNT(*) FROM "TABLE_A" GROUP BY "label";`,
+		args:   []interface{}{int64(20), "child", "adult"},
+		errmsg: "",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
@@ -102,6 +102,7 @@ type ColumnOption struct {
 	Size          int
 	SqlType       string
 	Default       interface{}
+	ForeignKey    *ForeignKey
 }
 
 // ColumnList represents list of Column.
@@ -139,11 +140,34 @@ type Column interface {
 	// Like creates Condition for "column LIKE right".  Type for right is column's one or other Column.
 	Like(right string) Condition
 
+	// NotLike creates Condition for "column NOT LIKE right".  Type for right is column's one or other Column.
+	NotLike(right string) Condition
+
 	// Between creates Condition for "column BETWEEN lower AND higher".  Type for lower/higher is int or time.Time.
 	Between(lower, higher interface{}) Condition
 
+	// NotBetween creates Condition for "column NOT BETWEEN lower AND higher".  Type for lower/higher is int or time.Time.
+	NotBetween(lower, higher interface{}) Condition
+
+	// IsNull creates Condition for "column IS NULL".
+	IsNull() Condition
+
+	// IsNotNull creates Condition for "column IS NOT NULL".
+	IsNotNull() Condition
+
 	// In creates Condition for "column IN (values[0], values[1] ...)".  Type for values is column's one or other Column.
+	// Or, give a *SelectStatement for "column IN (SELECT ...)".
 	In(values ...interface{}) Condition
+
+	// NotIn creates Condition for "column NOT IN (values[0], values[1] ...)".  Type for values is column's one or other Column.
+	// Or, give a *SelectStatement for "column NOT IN (SELECT ...)".
+	NotIn(values ...interface{}) Condition
+}
+
+// compositeColumn is a Column which consists of other columns.  ex: SqlFunc, Expression.
+type compositeColumn interface {
+	Column
+	columns() []Column
 }
 
 type aliasedColumn interface {
@@ -180,7 +204,7 @@ func (m *columnConfigImpl) toColumn(table Table) Column {
 }
 
 func (m *columnConfigImpl) serialize(bldr *builder) {
-	bldr.Append(dialect().QuoteField(m.name))
+	bldr.Append(bldr.QuoteIdent(m.name))
 	return
 }
 
@@ -198,14 +222,19 @@ func (m *columnImpl) config() ColumnConfig {
 }
 
 func (m *columnImpl) acceptType(val interface{}) bool {
+	return acceptLiteralType(m.typ, m.opt.NotNull, val)
+}
+
+// acceptLiteralType returns true if the val is a literal which can be handled as the typ.
+func acceptLiteralType(typ ColumnType, not_null bool, val interface{}) bool {
 	lit, ok := val.(literal)
 	if !ok || lit == nil {
 		return false
 	}
 	if lit.Raw() == nil {
-		return !m.opt.NotNull
+		return !not_null
 	}
-	if m.Type() == ColumnTypeAny {
+	if typ == ColumnTypeAny {
 		return true
 	}
 	if _, ok := lit.Raw().(sqldriver.Valuer); ok {
@@ -213,7 +242,7 @@ func (m *columnImpl) acceptType(val interface{}) bool {
 	}
 
 	valt := reflect.TypeOf(lit.Raw())
-	for _, t := range m.typ.CapableTypes() {
+	for _, t := range typ.CapableTypes() {
 		if t == valt {
 			return true
 		}
@@ -221,11 +250,29 @@ func (m *columnImpl) acceptType(val interface{}) bool {
 	return false
 }
 
+// literalType returns ColumnType for the literal.  returns ColumnTypeAny if it is unknown.
+func literalType(lit literal) ColumnType {
+	if lit == nil || lit.Raw() == nil {
+		return ColumnTypeAny
+	}
+	valt := reflect.TypeOf(lit.Raw())
+	for _, typ := range []ColumnType{ColumnTypeInt, ColumnTypeString, ColumnTypeDate, ColumnTypeFloat, ColumnTypeBool, ColumnTypeBytes} {
+		for _, t := range typ.CapableTypes() {
+			if t == valt {
+				return typ
+			}
+		}
+	}
+	return ColumnTypeAny
+}
+
 func (m *columnImpl) serialize(bldr *builder) {
 	if m == Star {
 		bldr.Append("*")
+	} else if bldr.constraint {
+		bldr.Append(bldr.QuoteIdent(m.name))
 	} else {
-		bldr.Append(dialect().QuoteField(m.table.Name()) + "." + dialect().QuoteField(m.name))
+		bldr.Append(qualifiedTableName(bldr, m.table) + "." + bldr.QuoteIdent(m.name))
 	}
 	return
 }
@@ -311,14 +358,34 @@ func (left *columnImpl) Like(right string) Condition {
 	return newBinaryOperationCondition(left, right, " LIKE ")
 }
 
+func (left *columnImpl) NotLike(right string) Condition {
+	return newBinaryOperationCondition(left, right, " NOT LIKE ")
+}
+
 func (left *columnImpl) Between(lower, higher interface{}) Condition {
 	return newBetweenCondition(left, lower, higher)
 }
 
+func (left *columnImpl) NotBetween(lower, higher interface{}) Condition {
+	return newNotBetweenCondition(left, lower, higher)
+}
+
 func (left *columnImpl) In(val ...interface{}) Condition {
 	return newInCondition(left, val...)
 }
 
+func (left *columnImpl) NotIn(val ...interface{}) Condition {
+	return newNotInCondition(left, val...)
+}
+
+func (left *columnImpl) IsNull() Condition {
+	return newNullCondition(left, false)
+}
+
+func (left *columnImpl) IsNotNull() Condition {
+	return newNullCondition(left, true)
+}
+
 func (b ColumnList) serialize(bldr *builder) {
 	first := true
 	for _, column := range b {
@@ -331,7 +398,50 @@ func (b ColumnList) serialize(bldr *builder) {
 		} else {
 			bldr.Append(", ")
 		}
-		bldr.Append(dialect().QuoteField(column.column_name()))
+		bldr.Append(bldr.QuoteIdent(column.column_name()))
+	}
+	return
+}
+
+// acceptColumnType returns true if values of the src column can be stored in the dst column.
+func acceptColumnType(dst, src Column) bool {
+	dcc, scc := dst.config(), src.config()
+	if dcc == nil || scc == nil {
+		return true
+	}
+	if dcc.Type() == ColumnTypeAny || scc.Type() == ColumnTypeAny {
+		return true
+	}
+	return dcc.Type() == scc.Type()
+}
+
+type returningColumnList []Column
+
+func (l returningColumnList) serialize(bldr *builder) {
+	if !bldr.dialect.SupportsReturning() {
+		bldr.SetError(newError("dialect does not support RETURNING clause."))
+		return
+	}
+
+	first := true
+	for _, column := range l {
+		if first {
+			first = false
+		} else {
+			bldr.Append(", ")
+		}
+		switch {
+		case column == Star:
+			bldr.Append("*")
+		case column.column_name() == "":
+			bldr.AppendItem(column)
+		default:
+			if ac, ok := column.(aliasedColumn); ok {
+				bldr.Append(bldr.QuoteIdent(ac.source().column_name()))
+				bldr.Append(" AS ")
+			}
+			bldr.Append(bldr.QuoteIdent(column.column_name()))
+		}
 	}
 	return
 }
@@ -395,14 +505,34 @@ func (left *errorColumn) Like(right string) Condition {
 	return newBinaryOperationCondition(left, right, " LIKE ")
 }
 
+func (left *errorColumn) NotLike(right string) Condition {
+	return newBinaryOperationCondition(left, right, " NOT LIKE ")
+}
+
 func (left *errorColumn) Between(lower, higher interface{}) Condition {
 	return newBetweenCondition(left, lower, higher)
 }
 
+func (left *errorColumn) NotBetween(lower, higher interface{}) Condition {
+	return newNotBetweenCondition(left, lower, higher)
+}
+
 func (left *errorColumn) In(val ...interface{}) Condition {
 	return newInCondition(left, val...)
 }
 
+func (left *errorColumn) NotIn(val ...interface{}) Condition {
+	return newNotInCondition(left, val...)
+}
+
+func (left *errorColumn) IsNull() Condition {
+	return newNullCondition(left, false)
+}
+
+func (left *errorColumn) IsNotNull() Condition {
+	return newNullCondition(left, true)
+}
+
 type aliasColumn struct {
 	column Column
 	alias  string
@@ -428,7 +558,7 @@ func (m *aliasColumn) As(alias string) Column {
 }
 
 func (m *aliasColumn) serialize(bldr *builder) {
-	bldr.Append(dialect().QuoteField(m.alias))
+	bldr.Append(bldr.QuoteIdent(m.alias))
 	return
 }
 
@@ -468,10 +598,30 @@ func (left *aliasColumn) Like(right string) Condition {
 	return newBinaryOperationCondition(left, right, " LIKE ")
 }
 
+func (left *aliasColumn) NotLike(right string) Condition {
+	return newBinaryOperationCondition(left, right, " NOT LIKE ")
+}
+
 func (left *aliasColumn) Between(lower, higher interface{}) Condition {
 	return newBetweenCondition(left, lower, higher)
 }
 
+func (left *aliasColumn) NotBetween(lower, higher interface{}) Condition {
+	return newNotBetweenCondition(left, lower, higher)
+}
+
 func (left *aliasColumn) In(val ...interface{}) Condition {
 	return newInCondition(left, val...)
 }
+
+func (left *aliasColumn) NotIn(val ...interface{}) Condition {
+	return newNotInCondition(left, val...)
+}
+
+func (left *aliasColumn) IsNull() Condition {
+	return newNullCondition(left, false)
+}
+
+func (left *aliasColumn) IsNotNull() Condition {
+	return newNullCondition(left, true)
+}
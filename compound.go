@@ -0,0 +1,238 @@
+package sqlbuilder
+
+type compoundType int
+
+const (
+	union_compound compoundType = iota
+	union_all_compound
+	intersect_compound
+	except_compound
+)
+
+// CompoundStatement represents a compound SELECT statement combined with UNION, UNION ALL, INTERSECT or EXCEPT.
+type CompoundStatement struct {
+	typ     compoundType
+	selects []*SelectStatement
+	orderBy []serializable
+	limit   int
+	offset  int
+
+	dialect Dialect
+
+	err error
+}
+
+// Union returns new compound statement combines the selects with "UNION" operator.
+func Union(selects ...*SelectStatement) *CompoundStatement {
+	return newCompoundStatement(union_compound, selects)
+}
+
+// UnionAll returns new compound statement combines the selects with "UNION ALL" operator.
+func UnionAll(selects ...*SelectStatement) *CompoundStatement {
+	return newCompoundStatement(union_all_compound, selects)
+}
+
+// Intersect returns new compound statement combines the selects with "INTERSECT" operator.
+func Intersect(selects ...*SelectStatement) *CompoundStatement {
+	return newCompoundStatement(intersect_compound, selects)
+}
+
+// Except returns new compound statement combines the selects with "EXCEPT" operator.
+func Except(selects ...*SelectStatement) *CompoundStatement {
+	return newCompoundStatement(except_compound, selects)
+}
+
+func newCompoundStatement(typ compoundType, selects []*SelectStatement) *CompoundStatement {
+	if len(selects) < 2 {
+		return &CompoundStatement{
+			err: newError("compound statement needs two or more SELECT statements."),
+		}
+	}
+	for _, s := range selects {
+		if s == nil {
+			return &CompoundStatement{
+				err: newError("SELECT statement is nil."),
+			}
+		}
+	}
+	return &CompoundStatement{
+		typ:     typ,
+		selects: selects,
+	}
+}
+
+// OrderBy sets "ORDER BY" clause. Use descending order if the desc is true, by the columns.
+// The columns are referred by its name(or alias) in the result of compound statement.
+// Use As() for expressions and functions in the result.
+func (b *CompoundStatement) OrderBy(desc bool, columns ...Column) *CompoundStatement {
+	if b.err != nil {
+		return b
+	}
+	if b.orderBy == nil {
+		b.orderBy = make([]serializable, 0)
+	}
+
+	for _, c := range columns {
+		if err := b.checkOrderBy(c); err != nil {
+			b.err = err
+			return b
+		}
+		b.orderBy = append(b.orderBy, newCompoundOrderBy(desc, c))
+	}
+	return b
+}
+
+// checkOrderBy returns an error if the column is not found in the result by its name.
+// Only the name is checked if the result columns are unknown(ex: SELECT *).
+func (b *CompoundStatement) checkOrderBy(column Column) error {
+	if column == nil {
+		return newError("column is nil.")
+	}
+	if _, ok := column.(*errorColumn); ok {
+		return nil
+	}
+	name := column.column_name()
+	if len(name) == 0 {
+		return newError("column of ORDER BY in compound statement has no name, use As() in SELECT statement.")
+	}
+	if selectColumnCount(b) < 0 {
+		return nil
+	}
+	for _, col := range b.selectColumns() {
+		if col.column_name() == name {
+			return nil
+		}
+	}
+	return newError("column %s of ORDER BY was not found in result of compound statement.", name)
+}
+
+// Limit sets LIMIT clause.
+func (b *CompoundStatement) Limit(limit int) *CompoundStatement {
+	if b.err != nil {
+		return b
+	}
+	b.limit = limit
+	return b
+}
+
+// Offset sets OFFSET clause.
+func (b *CompoundStatement) Offset(offset int) *CompoundStatement {
+	if b.err != nil {
+		return b
+	}
+	b.offset = offset
+	return b
+}
+
+func (b *CompoundStatement) serialize(bldr *builder) {
+	if b.err != nil {
+		bldr.SetError(b.err)
+		return
+	}
+
+	// check number of columns
+	expect := b.selects[0].columnCount()
+	for _, s := range b.selects[1:] {
+		got := s.columnCount()
+		if expect >= 0 && got >= 0 && expect != got {
+			bldr.SetError(newError("each SELECT statement in compound statement must have the same number of columns, but got %d and %d.", expect, got))
+			return
+		}
+	}
+
+	first := true
+	for _, s := range b.selects {
+		if first {
+			first = false
+		} else {
+			switch b.typ {
+			case union_compound:
+				bldr.Append(" UNION ")
+			case union_all_compound:
+				bldr.Append(" UNION ALL ")
+			case intersect_compound:
+				bldr.Append(" INTERSECT ")
+			case except_compound:
+				if bldr.dialect.QueryCapabilities()&QueryExceptOperator != 0 {
+					bldr.Append(" EXCEPT ")
+				} else {
+					bldr.Append(" MINUS ")
+				}
+			}
+		}
+		if s.orderBy != nil || s.limit != 0 || s.offset != 0 {
+			bldr.SetError(newError("ORDER BY, LIMIT and OFFSET can not be used in a part of compound statement."))
+			return
+		}
+		if s.lock != nil {
+			bldr.SetError(newError("locking clause can not be used in a part of compound statement."))
+			return
+		}
+		bldr.AppendItem(s)
+	}
+
+	// ORDER BY
+	if b.orderBy != nil {
+		bldr.Append(" ORDER BY ")
+		bldr.AppendItems(b.orderBy, ", ")
+	}
+
+	// LIMIT / OFFSET
+	serializePaging(bldr, &PagingOption{
+		Limit:   b.limit,
+		Offset:  b.offset,
+		OrderBy: b.orderBy != nil,
+	})
+	return
+}
+
+// ToSql generates query string, placeholder arguments, and returns err on errors.
+func (b *CompoundStatement) ToSql() (query string, args []interface{}, err error) {
+	return b.ToSqlWith(b.dialect)
+}
+
+// ToSqlWith generates query string, placeholder arguments with the d, and returns err on errors.
+// The default dialect is used if the d is nil.
+func (b *CompoundStatement) ToSqlWith(d Dialect) (query string, args []interface{}, err error) {
+	bldr := newBuilderWith(d)
+	bldr.AppendItem(b)
+	return bldr.Query(), bldr.Args(), bldr.Err()
+}
+
+// ToSubquery returns a Table which uses the statement as a subquery named by the alias.
+// Columns of the subquery are taken from the first SELECT statement.
+func (b *CompoundStatement) ToSubquery(alias string) Table {
+	return newSubquery(b, alias)
+}
+
+func (b *CompoundStatement) selectColumns() selectColumnList {
+	if len(b.selects) == 0 {
+		return nil
+	}
+	return b.selects[0].selectColumns()
+}
+
+type compoundOrderBy struct {
+	column Column
+	desc   bool
+}
+
+func newCompoundOrderBy(desc bool, column Column) *compoundOrderBy {
+	return &compoundOrderBy{
+		column: column,
+		desc:   desc,
+	}
+}
+
+func (m *compoundOrderBy) serialize(bldr *builder) {
+	if ec, ok := m.column.(*errorColumn); ok {
+		bldr.AppendItem(ec)
+		return
+	}
+	bldr.Append(bldr.QuoteIdent(m.column.column_name()))
+	if m.desc {
+		bldr.Append(" DESC")
+	} else {
+		bldr.Append(" ASC")
+	}
+}
@@ -0,0 +1,215 @@
+package sqlbuilder
+
+import (
+	"testing"
+)
+
+func TestCompound(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("test1", nil),
+		IntColumn("test2", nil),
+	)
+	table2 := NewTable(
+		"TABLE_B",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("test1", nil),
+	)
+
+	var cases = []statementTestCase{{
+		stmt: Union(
+			Select(table1).Columns(table1.C("id")).Where(table1.C("test1").Eq(1)),
+			Select(table2).Columns(table2.C("id")).Where(table2.C("test1").Eq(2)),
+		).OrderBy(true, table1.C("id")).Limit(10).Offset(20),
+		query: `SELECT "TABLE_A"."id" FROM "TABLE_A" WHERE "TABLE_A"."test1"=? ` +
+			`UNION SELECT "TABLE_B"."id" FROM "TABLE_B" WHERE "TABLE_B"."test1"=? ` +
+			`ORDER BY "id" DESC LIMIT ? OFFSET ?;`,
+		args:   []interface{}{int64(1), int64(2), 10, 20},
+		errmsg: "",
+	}, {
+		stmt: UnionAll(
+			Select(table1).Columns(table1.C("id")),
+			Select(table2).Columns(table2.C("id")),
+			Select(table1).Columns(table1.C("test1")),
+		),
+		query:  `SELECT "TABLE_A"."id" FROM "TABLE_A" UNION ALL SELECT "TABLE_B"."id" FROM "TABLE_B" UNION ALL SELECT "TABLE_A"."test1" FROM "TABLE_A";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt: Intersect(
+			Select(table1).Columns(table1.C("id"), table1.C("test1")),
+			Select(table2),
+		),
+		query:  `SELECT "TABLE_A"."id", "TABLE_A"."test1" FROM "TABLE_A" INTERSECT SELECT * FROM "TABLE_B";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt: Except(
+			Select(table1).Columns(table1.C("id")),
+			Select(table2).Columns(table2.C("id")),
+		),
+		query:  `SELECT "TABLE_A"."id" FROM "TABLE_A" EXCEPT SELECT "TABLE_B"."id" FROM "TABLE_B";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt: Union(
+			Select(table1).Columns(table1.C("id"), table1.C("test1")),
+			Select(table2).Columns(table2.C("id")),
+		),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: each SELECT statement in compound statement must have the same number of columns, but got 2 and 1.",
+	}, {
+		stmt: Union(
+			Select(table1),
+			Select(table2),
+		),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: each SELECT statement in compound statement must have the same number of columns, but got 3 and 2.",
+	}, {
+		stmt: Union(
+			Select(table1).Columns(table1.C("id")).Limit(1),
+			Select(table2).Columns(table2.C("id")),
+		),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: ORDER BY, LIMIT and OFFSET can not be used in a part of compound statement.",
+	}, {
+		stmt: Union(
+			Select(table1).Columns(table1.C("id")),
+			Select(table2).Columns(table2.C("id")).ForUpdate(),
+		),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: locking clause can not be used in a part of compound statement.",
+	}, {
+		stmt:   Union(Select(table1)),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: compound statement needs two or more SELECT statements.",
+	}, {
+		stmt:   Union(Select(table1), nil),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: SELECT statement is nil.",
+	}, {
+		stmt: Union(
+			Select(table1).Columns(table1.C("id")),
+			Select(nil),
+		),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: table is nil.",
+	}, {
+		stmt: Union(
+			Select(table1).Columns(table1.C("id"), Add(table1.C("test1"), 1)),
+			Select(table2).Columns(table2.C("id"), table2.C("test1")),
+		).OrderBy(false, Add(table1.C("test1"), 1)),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column of ORDER BY in compound statement has no name, use As() in SELECT statement.",
+	}, {
+		stmt: Union(
+			Select(table1).Columns(table1.C("id"), Add(table1.C("test1"), 1).As("t")),
+			Select(table2).Columns(table2.C("id"), table2.C("test1")),
+		).OrderBy(false, Add(table1.C("test1"), 1).As("t")),
+		query: `SELECT "TABLE_A"."id", "TABLE_A"."test1" + ? AS "t" FROM "TABLE_A" ` +
+			`UNION SELECT "TABLE_B"."id", "TABLE_B"."test1" FROM "TABLE_B" ORDER BY "t" ASC;`,
+		args:   []interface{}{int64(1)},
+		errmsg: "",
+	}, {
+		stmt: Union(
+			Select(table1).Columns(table1.C("id")),
+			Select(table2).Columns(table2.C("id")),
+		).OrderBy(false, table1.C("test1")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column test1 of ORDER BY was not found in result of compound statement.",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
+
+func TestCompoundNumberedBindVar(t *testing.T) {
+	SetDialect(NumberedTestDialect{})
+	defer SetDialect(TestDialect{})
+
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("test1", nil),
+	)
+
+	var cases = []statementTestCase{{
+		stmt: Union(
+			Select(table1).Columns(table1.C("id")).Where(table1.C("test1").Eq(1)),
+			Select(table1).Columns(table1.C("id")).Where(table1.C("test1").In(2, 3)),
+		).Limit(5),
+		query: `SELECT "TABLE_A"."id" FROM "TABLE_A" WHERE "TABLE_A"."test1"=$1 ` +
+			`UNION SELECT "TABLE_A"."id" FROM "TABLE_A" WHERE "TABLE_A"."test1" IN ( $2, $3 ) ` +
+			`LIMIT $4;`,
+		args:   []interface{}{int64(1), int64(2), int64(3), 5},
+		errmsg: "",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
+
+func TestCompoundSubquery(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("test1", nil),
+	)
+	table2 := NewTable(
+		"TABLE_B",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+	)
+
+	sq := Union(
+		Select(table1).Columns(table1.C("id")).Where(table1.C("test1").Eq(1)),
+		Select(table2).Columns(table2.C("id")),
+	).ToSubquery("SQ1")
+
+	var cases = []statementTestCase{{
+		stmt: Select(sq).Columns(sq.C("id")).Where(sq.C("id").Gt(2)),
+		query: `SELECT "SQ1"."id" FROM ( SELECT "TABLE_A"."id" FROM "TABLE_A" WHERE "TABLE_A"."test1"=? ` +
+			`UNION SELECT "TABLE_B"."id" FROM "TABLE_B" ) AS "SQ1" WHERE "SQ1"."id">?;`,
+		args:   []interface{}{int64(1), int64(2)},
+		errmsg: "",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
@@ -40,6 +40,40 @@ func (c *connectCondition) columns() []Column {
 	return list
 }
 
+type notCondition struct {
+	cond Condition
+}
+
+// Not creates a negated condition with "NOT" operator.
+func Not(cond Condition) Condition {
+	return &notCondition{
+		cond: cond,
+	}
+}
+
+func (c *notCondition) serialize(bldr *builder) {
+	if c.cond == nil {
+		bldr.SetError(newError("condition for NOT is nil."))
+		return
+	}
+	bldr.Append("NOT ")
+	if _, ok := c.cond.(*connectCondition); ok {
+		// if condition is AND or OR
+		bldr.Append("( ")
+		bldr.AppendItem(c.cond)
+		bldr.Append(" )")
+	} else {
+		bldr.AppendItem(c.cond)
+	}
+}
+
+func (c *notCondition) columns() []Column {
+	if c.cond == nil {
+		return []Column{}
+	}
+	return c.cond.columns()
+}
+
 // And creates a combined condition with "AND" operator.
 func And(conds ...Condition) Condition {
 	return &connectCondition{
@@ -72,6 +106,9 @@ func newBinaryOperationCondition(left, right interface{}, operator string) *bina
 	case Column:
 		column_exist = true
 		cond.left = t
+	case selectable:
+		column_exist = true
+		cond.left = newScalarSubquery(t)
 	case nil:
 		cond.err = newError("left-hand side of binary operator is null.")
 	default:
@@ -81,6 +118,8 @@ func newBinaryOperationCondition(left, right interface{}, operator string) *bina
 	case Column:
 		column_exist = true
 		cond.right = t
+	case selectable:
+		cond.right = newScalarSubquery(t)
 	default:
 		cond.right = toLiteral(t)
 	}
@@ -102,7 +141,83 @@ func newBetweenCondition(left Column, low, high interface{}) Condition {
 	}
 }
 
+func newNotBetweenCondition(left Column, low, high interface{}) Condition {
+	low_literal := toLiteral(low)
+	high_literal := toLiteral(high)
+
+	return &betweenCondition{
+		left:   left,
+		lower:  low_literal,
+		higher: high_literal,
+		not:    true,
+	}
+}
+
+// comparableType returns true if values of the a and b can be compared.
+func comparableType(a, b ColumnType) bool {
+	if a == ColumnTypeAny || b == ColumnTypeAny || a == b {
+		return true
+	}
+	numeric := func(t ColumnType) bool {
+		return t == ColumnTypeInt || t == ColumnTypeFloat
+	}
+	return numeric(a) && numeric(b)
+}
+
+// checkComparable returns error if the val can not be compared with the left.
+// This checks only comparisons with results of typed functions(ex: Count()), because
+// databases convert other values implicitly(ex: "int_column"='1').
+func checkComparable(left, val serializable) error {
+	if !typedFuncResult(left) && !typedFuncResult(val) {
+		return nil
+	}
+	col, ok := left.(Column)
+	if !ok {
+		col, ok = val.(Column)
+		val = left
+	}
+	if !ok {
+		return nil
+	}
+	typ := argType(col)
+	switch t := val.(type) {
+	case Column:
+		if !comparableType(typ, argType(t)) {
+			return newError("%s column not accept %s column.", typ.String(), argType(t).String())
+		}
+	case literal:
+		if t.IsNil() {
+			return nil
+		}
+		if !comparableType(typ, literalType(t)) {
+			return newError("%s column not accept %T.", typ.String(), t.Raw())
+		}
+	}
+	return nil
+}
+
+// typedFuncResult returns true if the v is a result of a function which has known type.
+func typedFuncResult(v serializable) bool {
+	switch t := v.(type) {
+	case *sqlFuncImpl:
+		return t.ctyp != ColumnTypeAny
+	case *aliasColumn:
+		return typedFuncResult(t.column)
+	}
+	return false
+}
+
 func (c *binaryOperationCondition) serialize(bldr *builder) {
+	if c.err != nil {
+		bldr.SetError(c.err)
+		return
+	}
+	if c.operator != " LIKE " && c.operator != " NOT LIKE " {
+		if err := checkComparable(c.left, c.right); err != nil {
+			bldr.SetError(err)
+			return
+		}
+	}
 	bldr.AppendItem(c.left)
 
 	switch t := c.right.(type) {
@@ -143,11 +258,22 @@ type betweenCondition struct {
 	left   serializable
 	lower  serializable
 	higher serializable
+	not    bool
 }
 
 func (c *betweenCondition) serialize(bldr *builder) {
+	for _, val := range []serializable{c.lower, c.higher} {
+		if err := checkComparable(c.left, val); err != nil {
+			bldr.SetError(err)
+			return
+		}
+	}
 	bldr.AppendItem(c.left)
-	bldr.Append(" BETWEEN ")
+	if c.not {
+		bldr.Append(" NOT BETWEEN ")
+	} else {
+		bldr.Append(" BETWEEN ")
+	}
 	bldr.AppendItem(c.lower)
 	bldr.Append(" AND ")
 	bldr.AppendItem(c.higher)
@@ -168,15 +294,57 @@ func (c *betweenCondition) columns() []Column {
 	return list
 }
 
+type nullCondition struct {
+	left Column
+	not  bool
+}
+
+func newNullCondition(left Column, not bool) Condition {
+	return &nullCondition{
+		left: left,
+		not:  not,
+	}
+}
+
+func (c *nullCondition) serialize(bldr *builder) {
+	bldr.AppendItem(c.left)
+	if c.not {
+		bldr.Append(" IS NOT NULL")
+	} else {
+		bldr.Append(" IS NULL")
+	}
+}
+
+func (c *nullCondition) columns() []Column {
+	return []Column{c.left}
+}
+
 type inCondition struct {
-	left serializable
-	in   []serializable
+	left     serializable
+	in       []serializable
+	subquery selectable
+	not      bool
 }
 
 func newInCondition(left Column, list ...interface{}) Condition {
+	return newInConditionImpl(left, false, list)
+}
+
+func newNotInCondition(left Column, list ...interface{}) Condition {
+	return newInConditionImpl(left, true, list)
+}
+
+func newInConditionImpl(left Column, not bool, list []interface{}) *inCondition {
 	m := &inCondition{
 		left: left,
 		in:   make([]serializable, 0, len(list)),
+		not:  not,
+	}
+	if len(list) == 1 {
+		if s, ok := list[0].(selectable); ok {
+			m.subquery = s
+			return m
+		}
 	}
 	for _, item := range list {
 		if c, ok := item.(Column); ok {
@@ -190,8 +358,26 @@ func newInCondition(left Column, list ...interface{}) Condition {
 
 func (c *inCondition) serialize(bldr *builder) {
 	bldr.AppendItem(c.left)
-	bldr.Append(" IN ( ")
-	bldr.AppendItems(c.in, ", ")
+	if c.not {
+		bldr.Append(" NOT IN ( ")
+	} else {
+		bldr.Append(" IN ( ")
+	}
+	for _, val := range c.in {
+		if err := checkComparable(c.left, val); err != nil {
+			bldr.SetError(err)
+			return
+		}
+	}
+	if c.subquery != nil {
+		if n := selectColumnCount(c.subquery); n != -1 && n != 1 {
+			bldr.SetError(newError("subquery for IN needs just one column, but got %d.", n))
+			return
+		}
+		bldr.AppendItem(c.subquery)
+	} else {
+		bldr.AppendItems(c.in, ", ")
+	}
 	bldr.Append(" )")
 }
 
@@ -207,3 +393,41 @@ func (c *inCondition) columns() []Column {
 	}
 	return list
 }
+
+type existsCondition struct {
+	stat *SelectStatement
+	not  bool
+}
+
+// Exists creates a condition for "EXISTS (SELECT ...)".
+func Exists(stat *SelectStatement) Condition {
+	return &existsCondition{
+		stat: stat,
+	}
+}
+
+// NotExists creates a condition for "NOT EXISTS (SELECT ...)".
+func NotExists(stat *SelectStatement) Condition {
+	return &existsCondition{
+		stat: stat,
+		not:  true,
+	}
+}
+
+func (c *existsCondition) serialize(bldr *builder) {
+	if c.stat == nil {
+		bldr.SetError(newError("subquery for EXISTS is nil."))
+		return
+	}
+	if c.not {
+		bldr.Append("NOT ")
+	}
+	bldr.Append("EXISTS ( ")
+	bldr.AppendItem(c.stat)
+	bldr.Append(" )")
+}
+
+func (c *existsCondition) columns() []Column {
+	// columns in the subquery are checked by the subquery itself.
+	return []Column{}
+}
@@ -235,3 +235,86 @@ func TestConnectCondition(t *testing.T) {
 		}
 	}
 }
+
+func TestNotCondition(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("test1", nil),
+		StringColumn("test2", nil),
+	)
+	cases := []conditionTestCase{{
+		cond:   table1.C("test1").IsNull(),
+		query:  `"TABLE_A"."test1" IS NULL`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		cond:   table1.C("test1").IsNotNull(),
+		query:  `"TABLE_A"."test1" IS NOT NULL`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		cond:   table1.C("test2").NotLike("%hoge%"),
+		query:  `"TABLE_A"."test2" NOT LIKE ?`,
+		args:   []interface{}{"%hoge%"},
+		errmsg: "",
+	}, {
+		cond:   table1.C("id").NotBetween(1, 2),
+		query:  `"TABLE_A"."id" NOT BETWEEN ? AND ?`,
+		args:   []interface{}{int64(1), int64(2)},
+		errmsg: "",
+	}, {
+		cond:   table1.C("id").NotIn(1, 2),
+		query:  `"TABLE_A"."id" NOT IN ( ?, ? )`,
+		args:   []interface{}{int64(1), int64(2)},
+		errmsg: "",
+	}, {
+		cond:   Func("count", table1.C("id")).IsNotNull(),
+		query:  `count("TABLE_A"."id") IS NOT NULL`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		cond:   table1.C("test1").As("t").IsNull(),
+		query:  `"t" IS NULL`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		cond:   Not(table1.C("id").Eq(1)),
+		query:  `NOT "TABLE_A"."id"=?`,
+		args:   []interface{}{int64(1)},
+		errmsg: "",
+	}, {
+		cond: Not(Or(
+			table1.C("id").Eq(1),
+			table1.C("test1").IsNull(),
+		)),
+		query:  `NOT ( "TABLE_A"."id"=? OR "TABLE_A"."test1" IS NULL )`,
+		args:   []interface{}{int64(1)},
+		errmsg: "",
+	}, {
+		cond: And(
+			Not(Or(
+				table1.C("id").Eq(1),
+				table1.C("id").Eq(2),
+			)),
+			table1.C("test1").IsNotNull(),
+		),
+		query:  `NOT ( "TABLE_A"."id"=? OR "TABLE_A"."id"=? ) AND "TABLE_A"."test1" IS NOT NULL`,
+		args:   []interface{}{int64(1), int64(2)},
+		errmsg: "",
+	}, {
+		cond:   Not(nil),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: condition for NOT is nil.",
+	}}
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
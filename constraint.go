@@ -0,0 +1,260 @@
+package sqlbuilder
+
+// ForeignKeyAction represents an action of ON DELETE / ON UPDATE clause.
+type ForeignKeyAction int
+
+const (
+	// no clause is rendered.  Database's default(NO ACTION) is used.
+	ForeignKeyNoAction ForeignKeyAction = iota
+	// CASCADE
+	ForeignKeyCascade
+	// SET NULL
+	ForeignKeySetNull
+	// RESTRICT
+	ForeignKeyRestrict
+)
+
+func (m ForeignKeyAction) String() string {
+	switch m {
+	case ForeignKeyNoAction:
+		return "NO ACTION"
+	case ForeignKeyCascade:
+		return "CASCADE"
+	case ForeignKeySetNull:
+		return "SET NULL"
+	case ForeignKeyRestrict:
+		return "RESTRICT"
+	}
+	return ""
+}
+
+// UniqueKey represents a UNIQUE constraint.  Constraint name is omitted if Name is empty.
+type UniqueKey struct {
+	Name    string
+	Columns []string
+}
+
+// Check represents a CHECK constraint.  Constraint name is omitted if Name is empty.
+// Values in the Condition are written as literals, not placeholders.
+type Check struct {
+	Name      string
+	Condition Condition
+}
+
+// ForeignKey represents a foreign key constraint.
+// Columns is names of referencing columns, and References is referenced columns of a natural table.
+// In ColumnOption, Columns is not needed.
+// Dialects handle OnDelete and OnUpdate for know actions.
+type ForeignKey struct {
+	Name       string
+	Columns    []string
+	References []Column
+	OnDelete   ForeignKeyAction
+	OnUpdate   ForeignKeyAction
+}
+
+// equalForeignKey returns true if the a and b are the same foreign key.  Referenced columns are compared by identity.
+func equalForeignKey(a, b *ForeignKey) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Name != b.Name || a.OnDelete != b.OnDelete || a.OnUpdate != b.OnUpdate ||
+		len(a.Columns) != len(b.Columns) || len(a.References) != len(b.References) {
+		return false
+	}
+	for i := range a.Columns {
+		if a.Columns[i] != b.Columns[i] {
+			return false
+		}
+	}
+	for i := range a.References {
+		if a.References[i] != b.References[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// foreignKeyConstraint is a foreign key constraint for the table.
+// Existence of the columns is not checked if the table is nil.
+type foreignKeyConstraint struct {
+	table Table
+	fk    *ForeignKey
+}
+
+func (m foreignKeyConstraint) serialize(bldr *builder) {
+	if len(m.fk.Columns) == 0 || len(m.fk.Columns) != len(m.fk.References) {
+		bldr.SetError(newError("foreign key needs same number of columns and referenced columns."))
+		return
+	}
+
+	var ref_table Table
+	for _, ref := range m.fk.References {
+		col, ok := ref.(*columnImpl)
+		if !ok || col == Star {
+			bldr.SetError(newError("referenced column of foreign key must be a column of natural table."))
+			return
+		}
+		if _, ok := col.table.(*table); !ok {
+			bldr.SetError(newError("referenced column of foreign key must be a column of natural table."))
+			return
+		}
+		if ref_table == nil {
+			ref_table = col.table
+		} else if ref_table != col.table {
+			bldr.SetError(newError("referenced columns of foreign key must belong to one table."))
+			return
+		}
+	}
+
+	if len(m.fk.Name) != 0 {
+		bldr.Append("CONSTRAINT " + bldr.QuoteIdent(m.fk.Name) + " ")
+	}
+	bldr.Append("FOREIGN KEY ( ")
+	for i, name := range m.fk.Columns {
+		if m.table != nil {
+			if _, ok := m.table.C(name).(*errorColumn); ok {
+				bldr.SetError(newError("column %s of foreign key was not found.", name))
+				return
+			}
+		}
+		if i != 0 {
+			bldr.Append(", ")
+		}
+		bldr.Append(bldr.QuoteIdent(name))
+	}
+	bldr.Append(" ) REFERENCES ")
+	bldr.AppendItem(ref_table)
+	bldr.Append(" ( ")
+	for i, ref := range m.fk.References {
+		if i != 0 {
+			bldr.Append(", ")
+		}
+		bldr.Append(bldr.QuoteIdent(ref.column_name()))
+	}
+	bldr.Append(" )")
+
+	actions, err := bldr.dialect.ForeignKeyActionToString(m.fk)
+	if err != nil {
+		bldr.SetError(err)
+		return
+	}
+	if len(actions) != 0 {
+		bldr.Append(" " + actions)
+	}
+}
+
+// checkConstraint is a CHECK constraint for the table.
+type checkConstraint struct {
+	table Table
+	chk   *Check
+}
+
+func (m checkConstraint) serialize(bldr *builder) {
+	if m.chk.Condition == nil {
+		bldr.SetError(newError("condition of CHECK constraint is nil."))
+		return
+	}
+	for _, col := range m.chk.Condition.columns() {
+		if !m.table.hasColumn(col) {
+			bldr.SetError(newError("column of CHECK constraint must belong to the table."))
+			return
+		}
+	}
+
+	if len(m.chk.Name) != 0 {
+		bldr.Append("CONSTRAINT " + bldr.QuoteIdent(m.chk.Name) + " ")
+	}
+	bldr.Append("CHECK ( ")
+	bldr.constraint = true
+	bldr.AppendItem(m.chk.Condition)
+	bldr.constraint = false
+	bldr.Append(" )")
+}
+
+// checkTableOption returns error if the option of the tbl refers unknown columns, or has more than one primary key.
+func checkTableOption(tbl Table) error {
+	opt := tbl.Option()
+	if opt == nil {
+		return nil
+	}
+	hasColumn := func(name string) bool {
+		_, ok := tbl.C(name).(*errorColumn)
+		return !ok
+	}
+
+	pk_columns := 0
+	for _, col := range tbl.Columns() {
+		if col.config().Option().PrimaryKey {
+			pk_columns++
+		}
+	}
+	if len(opt.PrimaryKey) != 0 && pk_columns != 0 {
+		return newError("primary key is set in both of TableOption and ColumnOption.")
+	}
+	if pk_columns > 1 {
+		return newError("primary key is set to %d columns, use TableOption.PrimaryKey for composite primary key.", pk_columns)
+	}
+
+	for _, name := range opt.PrimaryKey {
+		if !hasColumn(name) {
+			return newError("column %s of primary key was not found.", name)
+		}
+	}
+	for _, unique := range opt.Unique {
+		for _, name := range unique {
+			if !hasColumn(name) {
+				return newError("column %s of unique key was not found.", name)
+			}
+		}
+	}
+	for _, unique := range opt.UniqueKeys {
+		if len(unique.Columns) == 0 {
+			return newError("unique key needs one or more columns.")
+		}
+		for _, name := range unique.Columns {
+			if !hasColumn(name) {
+				return newError("column %s of unique key was not found.", name)
+			}
+		}
+	}
+	return nil
+}
+
+// tableConstraints returns table constraints of the tbl which are written in CREATE TABLE statement by the builder.
+// Primary key and unique keys are written by dialects.  Foreign keys in column options are written as table constraints.
+func tableConstraints(tbl Table) []serializable {
+	list := make([]serializable, 0)
+	for _, col := range tbl.Columns() {
+		list = append(list, columnConstraints(tbl, col.config())...)
+	}
+	if opt := tbl.Option(); opt != nil {
+		for i := range opt.Checks {
+			list = append(list, checkConstraint{
+				table: tbl,
+				chk:   &opt.Checks[i],
+			})
+		}
+		for i := range opt.ForeignKeys {
+			list = append(list, foreignKeyConstraint{
+				table: tbl,
+				fk:    &opt.ForeignKeys[i],
+			})
+		}
+	}
+	return list
+}
+
+// columnConstraints returns table constraints defined in the option of the cc.
+func columnConstraints(tbl Table, cc ColumnConfig) []serializable {
+	list := make([]serializable, 0)
+	if fk := cc.Option().ForeignKey; fk != nil {
+		cfk := *fk
+		cfk.Columns = []string{cc.Name()}
+		list = append(list, foreignKeyConstraint{
+			table: tbl,
+			fk:    &cfk,
+		})
+	}
+	return list
+}
@@ -1,11 +1,32 @@
 package sqlbuilder
 
+// IndexCapability represents features of index which a dialect supports.
+type IndexCapability int
+
+const (
+	// CREATE INDEX ... WHERE (partial index)
+	IndexPartial IndexCapability = 1 << iota
+	// CREATE INDEX ... USING method
+	IndexUsing
+	// CREATE INDEX ... ( expression )
+	IndexExpression
+	// DROP INDEX name ON table
+	IndexDropOnTable
+	// DROP INDEX IF EXISTS ...
+	IndexDropIfExists
+)
+
 // CreateIndexStatement represents a "CREATE INDEX" statement.
 type CreateIndexStatement struct {
 	table       Table
-	columns     []Column
+	columns     []indexElement
 	name        string
 	ifNotExists bool
+	unique      bool
+	where       Condition
+	using       string
+
+	dialect Dialect
 
 	err error
 }
@@ -15,6 +36,8 @@ type CreateTableStatement struct {
 	table       Table
 	ifNotExists bool
 
+	dialect Dialect
+
 	err error
 }
 
@@ -45,9 +68,15 @@ func (b *CreateTableStatement) IfNotExists() *CreateTableStatement {
 	return b
 }
 
-// ToSql generates query string, placeholder arguments, and error.
+// ToSql generates query string, placeholder arguments, and returns err on errors.
 func (b *CreateTableStatement) ToSql() (query string, args []interface{}, err error) {
-	bldr := newBuilder()
+	return b.ToSqlWith(b.dialect)
+}
+
+// ToSqlWith generates query string, placeholder arguments with the d, and returns err on errors.
+// The default dialect is used if the d is nil.
+func (b *CreateTableStatement) ToSqlWith(d Dialect) (query string, args []interface{}, err error) {
+	bldr := newBuilderWith(d)
 	defer func() {
 		query, args, err = bldr.Query(), bldr.Args(), bldr.Err()
 	}()
@@ -63,23 +92,36 @@ func (b *CreateTableStatement) ToSql() (query string, args []interface{}, err er
 	bldr.AppendItem(b.table)
 
 	if len(b.table.Columns()) != 0 {
+		if e := checkTableOption(b.table); e != nil {
+			bldr.SetError(e)
+			return
+		}
 		bldr.Append(" ( ")
 		bldr.AppendItem(createTableColumnList(b.table.Columns()))
+
+		// table constraints
+		for _, unique := range b.table.Option().UniqueKeys {
+			if len(unique.Name) != 0 && !bldr.CheckIdent(unique.Name) {
+				return
+			}
+		}
+		if tabopt, err := bldr.dialect.TableOptionToString(b.table.Option()); err == nil {
+			if len(tabopt) != 0 {
+				bldr.Append(", " + tabopt)
+			}
+		} else {
+			bldr.SetError(err)
+		}
+		if constraints := tableConstraints(b.table); len(constraints) != 0 {
+			bldr.Append(", ")
+			bldr.AppendItems(constraints, ", ")
+		}
 		bldr.Append(" )")
 	} else {
 		bldr.SetError(newError("CreateTableStatement needs one or more columns."))
 		return
 	}
 
-	// table option
-	if tabopt, err := dialect().TableOptionToString(b.table.Option()); err == nil {
-		if len(tabopt) != 0 {
-			bldr.Append(" " + tabopt)
-		}
-	} else {
-		bldr.SetError(err)
-	}
-
 	return
 }
 
@@ -109,12 +151,65 @@ func (b *CreateIndexStatement) IfNotExists() *CreateIndexStatement {
 	return b
 }
 
-// IfNotExists sets "IF NOT EXISTS" clause. If not set this, returns error on ToSql().
+// Columns sets columns of the index. If not set this, returns error on ToSql().
+// SqlFunc or Expression can be used for expression index.
 func (b *CreateIndexStatement) Columns(columns ...Column) *CreateIndexStatement {
 	if b.err != nil {
 		return b
 	}
-	b.columns = columns
+	b.columns = make([]indexElement, 0, len(columns))
+	for _, col := range columns {
+		b.columns = append(b.columns, indexElement{column: col})
+	}
+	return b
+}
+
+// OrderBy adds columns of the index with sort order.  Use descending order if the desc is true.
+func (b *CreateIndexStatement) OrderBy(desc bool, columns ...Column) *CreateIndexStatement {
+	if b.err != nil {
+		return b
+	}
+	for _, col := range columns {
+		b.columns = append(b.columns, indexElement{column: col, ordered: true, desc: desc})
+	}
+	return b
+}
+
+// Unique sets "UNIQUE" to create unique index.
+func (b *CreateIndexStatement) Unique() *CreateIndexStatement {
+	if b.err != nil {
+		return b
+	}
+	b.unique = true
+	return b
+}
+
+// Where sets WHERE clause to create partial index.  Values in the cond are written as literals.
+func (b *CreateIndexStatement) Where(cond Condition) *CreateIndexStatement {
+	if b.err != nil {
+		return b
+	}
+	b.where = cond
+	return b
+}
+
+// Using sets "USING" clause.  The method is index method like btree, hash or gin.
+// The method is written without quoting, so it accepts only letters, digits and underscore.
+func (b *CreateIndexStatement) Using(method string) *CreateIndexStatement {
+	if b.err != nil {
+		return b
+	}
+	if len(method) == 0 {
+		b.err = newError("index method is empty.")
+		return b
+	}
+	for _, c := range method {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			b.err = newError("index method %q contains invalid character.", method)
+			return b
+		}
+	}
+	b.using = method
 	return b
 }
 
@@ -130,7 +225,13 @@ func (b *CreateIndexStatement) Name(name string) *CreateIndexStatement {
 
 // ToSql generates query string, placeholder arguments, and returns err on errors.
 func (b *CreateIndexStatement) ToSql() (query string, args []interface{}, err error) {
-	bldr := newBuilder()
+	return b.ToSqlWith(b.dialect)
+}
+
+// ToSqlWith generates query string, placeholder arguments with the d, and returns err on errors.
+// The default dialect is used if the d is nil.
+func (b *CreateIndexStatement) ToSqlWith(d Dialect) (query string, args []interface{}, err error) {
+	bldr := newBuilderWith(d)
 	defer func() {
 		query, args, err = bldr.Query(), bldr.Args(), bldr.Err()
 	}()
@@ -139,13 +240,19 @@ func (b *CreateIndexStatement) ToSql() (query string, args []interface{}, err er
 		return
 	}
 
-	bldr.Append("CREATE INDEX ")
+	caps := bldr.dialect.IndexCapabilities()
+
+	bldr.Append("CREATE ")
+	if b.unique {
+		bldr.Append("UNIQUE ")
+	}
+	bldr.Append("INDEX ")
 	if b.ifNotExists {
 		bldr.Append("IF NOT EXISTS ")
 	}
 
 	if len(b.name) != 0 {
-		bldr.Append(dialect().QuoteField(b.name))
+		bldr.Append(bldr.QuoteIdent(b.name))
 	} else {
 		bldr.SetError(newError("name was not setted."))
 		return
@@ -154,14 +261,54 @@ func (b *CreateIndexStatement) ToSql() (query string, args []interface{}, err er
 	bldr.Append(" ON ")
 	bldr.AppendItem(b.table)
 
+	if len(b.using) != 0 {
+		if caps&IndexUsing == 0 {
+			bldr.SetError(newError("dialect does not support USING clause in CREATE INDEX statement."))
+			return
+		}
+		bldr.Append(" USING " + b.using)
+	}
+
+	// columns and WHERE clause are written with literals and without table name.
+	bldr.constraint = true
+	defer func() {
+		bldr.constraint = false
+	}()
+
 	if len(b.columns) != 0 {
+		for _, elm := range b.columns {
+			if !b.table.hasColumn(elm.column) {
+				bldr.SetError(newError("column of index must belong to the table."))
+				return
+			}
+		}
 		bldr.Append(" ( ")
-		bldr.AppendItem(createIndexColumnList(b.columns))
+		for i, elm := range b.columns {
+			if i != 0 {
+				bldr.Append(", ")
+			}
+			elm.serialize(bldr, caps)
+		}
 		bldr.Append(" )")
 	} else {
 		bldr.SetError(newError("columns was not setted."))
 		return
 	}
+
+	if b.where != nil {
+		if caps&IndexPartial == 0 {
+			bldr.SetError(newError("dialect does not support WHERE clause in CREATE INDEX statement."))
+			return
+		}
+		for _, col := range b.where.columns() {
+			if !b.table.hasColumn(col) {
+				bldr.SetError(newError("column of index must belong to the table."))
+				return
+			}
+		}
+		bldr.Append(" WHERE ")
+		bldr.AppendItem(b.where)
+	}
 	return
 }
 
@@ -182,13 +329,13 @@ func (m createTableColumnList) serialize(bldr *builder) {
 		bldr.Append(" ")
 
 		// SQL data name
-		str, err := dialect().ColumnTypeToString(cc)
+		str, err := bldr.dialect.ColumnTypeToString(cc)
 		if err != nil {
 			bldr.SetError(err)
 		}
 		bldr.Append(str)
 
-		str, err = dialect().ColumnOptionToString(cc.Option())
+		str, err = bldr.dialect.ColumnOptionToString(cc.Option())
 		if err != nil {
 			bldr.SetError(err)
 		}
@@ -198,17 +345,42 @@ func (m createTableColumnList) serialize(bldr *builder) {
 	}
 }
 
-type createIndexColumnList []Column
+// indexElement is a column or an expression of index.
+type indexElement struct {
+	column  Column
+	ordered bool
+	desc    bool
+}
 
-func (m createIndexColumnList) serialize(bldr *builder) {
-	first := true
-	for _, column := range m {
-		if first {
-			first = false
+func (m indexElement) serialize(bldr *builder, caps IndexCapability) {
+	switch col := m.column.(type) {
+	case *columnImpl:
+		if col == Star {
+			bldr.SetError(newError("column of index must be a column or an expression."))
+			return
+		}
+		bldr.AppendItem(col)
+	case *aliasColumn:
+		bldr.SetError(newError("column of index must be a column or an expression."))
+		return
+	case compositeColumn:
+		if caps&IndexExpression == 0 {
+			bldr.SetError(newError("dialect does not support expression index."))
+			return
+		}
+		bldr.Append("( ")
+		bldr.AppendItem(col)
+		bldr.Append(" )")
+	default:
+		bldr.SetError(newError("column of index must be a column or an expression."))
+		return
+	}
+
+	if m.ordered {
+		if m.desc {
+			bldr.Append(" DESC")
 		} else {
-			bldr.Append(", ")
+			bldr.Append(" ASC")
 		}
-		cc := column.config()
-		bldr.AppendItem(cc)
 	}
 }
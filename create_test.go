@@ -66,7 +66,7 @@ func TestCreate(t *testing.T) {
 		errmsg: "",
 	}, {
 		stmt:   CreateTable(table3).IfNotExists(),
-		query:  `CREATE TABLE IF NOT EXISTS "TABLE_C" ( "id" INTEGER PRIMARY KEY AUTOINCREMENT, "test1" INTEGER UNIQUE, "test2" TEXT ) UNIQUE("test1", "test2");`,
+		query:  `CREATE TABLE IF NOT EXISTS "TABLE_C" ( "id" INTEGER PRIMARY KEY AUTOINCREMENT, "test1" INTEGER UNIQUE, "test2" TEXT, UNIQUE("test1", "test2") );`,
 		args:   []interface{}{},
 		errmsg: "",
 	}, {
@@ -108,3 +108,243 @@ func TestCreate(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateTableForeignKey(t *testing.T) {
+	parent := NewTable(
+		"PARENT",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("sub_id", nil),
+	)
+	child := NewTable(
+		"CHILD",
+		&TableOption{
+			ForeignKeys: []ForeignKey{{
+				Name:       "FK_PARENT",
+				Columns:    []string{"parent_id", "parent_sub_id"},
+				References: []Column{parent.C("id"), parent.C("sub_id")},
+				OnDelete:   ForeignKeyCascade,
+				OnUpdate:   ForeignKeyRestrict,
+			}},
+		},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("parent_id", nil),
+		IntColumn("parent_sub_id", nil),
+		IntColumn("owner_id", &ColumnOption{
+			ForeignKey: &ForeignKey{
+				References: []Column{parent.C("id")},
+				OnDelete:   ForeignKeySetNull,
+			},
+		}),
+	)
+	newChild := func(fk ForeignKey) Table {
+		return NewTable(
+			"CHILD",
+			&TableOption{
+				ForeignKeys: []ForeignKey{fk},
+			},
+			IntColumn("parent_id", nil),
+		)
+	}
+	subquery := Select(parent).Columns(parent.C("id")).ToSubquery("SQ")
+
+	var cases = []statementTestCase{{
+		stmt:   CreateTable(child),
+		query:  `CREATE TABLE "CHILD" ( "id" INTEGER PRIMARY KEY, "parent_id" INTEGER, "parent_sub_id" INTEGER, "owner_id" INTEGER, FOREIGN KEY ( "owner_id" ) REFERENCES "PARENT" ( "id" ) ON DELETE SET NULL, CONSTRAINT "FK_PARENT" FOREIGN KEY ( "parent_id", "parent_sub_id" ) REFERENCES "PARENT" ( "id", "sub_id" ) ON DELETE CASCADE ON UPDATE RESTRICT );`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt: CreateTable(newChild(ForeignKey{
+			Columns:    []string{"parent_id"},
+			References: []Column{subquery.C("id")},
+		})),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: referenced column of foreign key must be a column of natural table.",
+	}, {
+		stmt: CreateTable(newChild(ForeignKey{
+			Columns:    []string{"parent_id"},
+			References: []Column{parent.C("id"), parent.C("sub_id")},
+		})),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: foreign key needs same number of columns and referenced columns.",
+	}, {
+		stmt: CreateTable(newChild(ForeignKey{
+			Columns:    []string{"not_found"},
+			References: []Column{parent.C("id")},
+		})),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column not_found of foreign key was not found.",
+	}, {
+		stmt: AlterTable(child).AddForeignKey(ForeignKey{
+			Name:       "FK_OWNER",
+			Columns:    []string{"owner_id"},
+			References: []Column{parent.C("id")},
+			OnDelete:   ForeignKeyCascade,
+		}),
+		query:  `ALTER TABLE "CHILD" ADD CONSTRAINT "FK_OWNER" FOREIGN KEY ( "owner_id" ) REFERENCES "PARENT" ( "id" ) ON DELETE CASCADE;`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt: AlterTable(child).AddColumn(IntColumn("editor_id", &ColumnOption{
+			ForeignKey: &ForeignKey{
+				References: []Column{parent.C("id")},
+			},
+		})),
+		query:  `ALTER TABLE "CHILD" ADD COLUMN "editor_id" INTEGER, ADD FOREIGN KEY ( "editor_id" ) REFERENCES "PARENT" ( "id" );`,
+		args:   []interface{}{},
+		errmsg: "",
+	}}
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
+
+func TestCreateTableConstraints(t *testing.T) {
+	other := NewTable(
+		"OTHER",
+		&TableOption{},
+		IntColumn("id", nil),
+	)
+	newTable := func(opt *TableOption, pk bool) Table {
+		return NewTable(
+			"TABLE_A",
+			opt,
+			IntColumn("id", &ColumnOption{
+				PrimaryKey: pk,
+			}),
+			IntColumn("sub_id", &ColumnOption{
+				PrimaryKey: pk,
+			}),
+			StringColumn("status", nil),
+		)
+	}
+	table1 := newTable(&TableOption{
+		PrimaryKey: []string{"id", "sub_id"},
+		UniqueKeys: []UniqueKey{{
+			Name:    "U_STATUS",
+			Columns: []string{"sub_id", "status"},
+		}, {
+			Columns: []string{"status"},
+		}},
+	}, false)
+	opt1 := table1.Option()
+	opt1.Checks = []Check{{
+		Name:      "C_ID",
+		Condition: table1.C("id").Gt(0),
+	}, {
+		Condition: Or(table1.C("status").In("it's", "ok"), table1.C("status").IsNull()),
+	}}
+
+	var cases = []statementTestCase{{
+		stmt:   CreateTable(table1),
+		query:  `CREATE TABLE "TABLE_A" ( "id" INTEGER, "sub_id" INTEGER, "status" TEXT, PRIMARY KEY("id", "sub_id"), CONSTRAINT "U_STATUS" UNIQUE("sub_id", "status"), UNIQUE("status"), CONSTRAINT "C_ID" CHECK ( "id">0 ), CHECK ( "status" IN ( 'it''s', 'ok' ) OR "status" IS NULL ) );`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   CreateTable(newTable(&TableOption{}, true)),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: primary key is set to 2 columns, use TableOption.PrimaryKey for composite primary key.",
+	}, {
+		stmt: CreateTable(NewTable(
+			"TABLE_B",
+			&TableOption{PrimaryKey: []string{"id"}},
+			IntColumn("id", &ColumnOption{PrimaryKey: true}),
+		)),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: primary key is set in both of TableOption and ColumnOption.",
+	}, {
+		stmt:   CreateTable(newTable(&TableOption{PrimaryKey: []string{"id", "not_found"}}, false)),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column not_found of primary key was not found.",
+	}, {
+		stmt:   CreateTable(newTable(&TableOption{UniqueKeys: []UniqueKey{{Name: "U"}}}, false)),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: unique key needs one or more columns.",
+	}, {
+		stmt:   CreateTable(newTable(&TableOption{Checks: []Check{{Condition: other.C("id").Gt(0)}}}, false)),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column of CHECK constraint must belong to the table.",
+	}}
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
+
+func TestCreateIndexOptions(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		StringColumn("name", nil),
+		BoolColumn("deleted", nil),
+	)
+	table2 := NewTable(
+		"TABLE_B",
+		&TableOption{},
+		IntColumn("id", nil),
+	)
+
+	var cases = []statementTestCase{{
+		stmt:   CreateIndex(table1).Name("I_NAME").Unique().Columns(table1.C("name")),
+		query:  `CREATE UNIQUE INDEX "I_NAME" ON "TABLE_A" ( "name" );`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   CreateIndex(table1).Name("I_NAME").OrderBy(false, table1.C("name")).OrderBy(true, table1.C("id")),
+		query:  `CREATE INDEX "I_NAME" ON "TABLE_A" ( "name" ASC, "id" DESC );`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   CreateIndex(table1).Name("I_NAME").Columns(table1.C("name")).Where(table1.C("deleted").Eq(false)),
+		query:  `CREATE INDEX "I_NAME" ON "TABLE_A" ( "name" ) WHERE "deleted"=FALSE;`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   CreateIndex(table1).Name("I_NAME").Using("btree").Columns(Lower(table1.C("name"))),
+		query:  `CREATE INDEX "I_NAME" ON "TABLE_A" USING btree ( ( LOWER("name") ) );`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   CreateIndex(table1).Name("I_NAME").Using("btree; DROP TABLE x").Columns(table1.C("name")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: `sqlbuilder: index method "btree; DROP TABLE x" contains invalid character.`,
+	}, {
+		stmt:   CreateIndex(table1).Name("I_NAME").Columns(table2.C("id")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column of index must belong to the table.",
+	}, {
+		stmt:   CreateIndex(table1).Name("I_NAME").Columns(table1.C("name").As("n")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column of index must be a column or an expression.",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
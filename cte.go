@@ -0,0 +1,303 @@
+package sqlbuilder
+
+// CTE represents a common table expression used with WITH clause.
+// The CTE can be handled in same way as a table.
+type CTE struct {
+	name      string
+	stat      selectable
+	columns   []Column
+	recursive bool
+
+	err error
+}
+
+// NewCTE returns a new common table expression named by the name and defined by the stat.
+// The stat must be a *SelectStatement or a *CompoundStatement.  Columns of the CTE are taken from the stat.
+func NewCTE(name string, stat Statement) *CTE {
+	m := &CTE{
+		name: name,
+	}
+	if len(name) == 0 {
+		m.err = newError("name of CTE is empty.")
+		return m
+	}
+	switch s := stat.(type) {
+	case nil:
+		m.err = newError("statement for CTE is nil.")
+	case *SelectStatement:
+		if s == nil {
+			m.err = newError("statement for CTE is nil.")
+		} else {
+			m.stat = s
+		}
+	case *CompoundStatement:
+		if s == nil {
+			m.err = newError("statement for CTE is nil.")
+		} else {
+			m.stat = s
+		}
+	default:
+		m.err = newError("CTE can use only SELECT statement.")
+	}
+	return m
+}
+
+// NewRecursiveCTE returns a new recursive common table expression named by the name.
+// Specify columns of the CTE by the column_configs, and set its definition with UnionAll().
+func NewRecursiveCTE(name string, column_configs ...ColumnConfig) *CTE {
+	m := &CTE{
+		name:      name,
+		recursive: true,
+		columns:   make([]Column, 0, len(column_configs)),
+	}
+	if len(name) == 0 {
+		m.err = newError("name of CTE is empty.")
+		return m
+	}
+	if len(column_configs) == 0 {
+		m.err = newError("column is needed.")
+		return m
+	}
+	for _, cc := range column_configs {
+		m.columns = append(m.columns, cc.toColumn(m))
+	}
+	return m
+}
+
+// UnionAll sets definition of the recursive CTE.  The anchor is non-recursive part, and
+// the recursive refers the CTE itself.  These are combined with "UNION ALL" operator.
+// Both of the anchor and the recursive must select same number of columns as the CTE.
+func (m *CTE) UnionAll(anchor, recursive *SelectStatement) *CTE {
+	if m.err != nil {
+		return m
+	}
+	if !m.recursive {
+		m.err = newError("CTE is not recursive.")
+		return m
+	}
+	for _, s := range []*SelectStatement{anchor, recursive} {
+		if s == nil {
+			continue
+		}
+		if n := selectColumnCount(s); n != -1 && n != len(m.columns) {
+			m.err = newError("CTE %s has %d columns, but SELECT statement has %d columns.", m.name, len(m.columns), n)
+			return m
+		}
+	}
+	m.stat = UnionAll(anchor, recursive)
+	return m
+}
+
+func (m *CTE) serialize(bldr *builder) {
+	if m.err != nil {
+		bldr.SetError(m.err)
+		return
+	}
+	bldr.Append(bldr.QuoteIdent(m.name))
+	return
+}
+
+// serializeDefinition serializes the CTE for WITH clause.
+func (m *CTE) serializeDefinition(bldr *builder) {
+	if m.err != nil {
+		bldr.SetError(m.err)
+		return
+	}
+	if m.stat == nil {
+		bldr.SetError(newError("CTE %s has no definition.", m.name))
+		return
+	}
+	bldr.Append(bldr.QuoteIdent(m.name))
+	if m.recursive {
+		bldr.Append(" ( ")
+		bldr.AppendItem(ColumnList(m.columns))
+		bldr.Append(" )")
+	}
+	bldr.Append(" AS ( ")
+	bldr.AppendItem(m.stat)
+	bldr.Append(" )")
+}
+
+// C returns CTE's column by the name.
+func (m *CTE) C(name string) Column {
+	for _, col := range m.Columns() {
+		if col.column_name() == name {
+			return col
+		}
+	}
+	return newErrorColumn(newError("column %s.%s was not found.", m.name, name))
+}
+
+// Name returns CTE's name.
+func (m *CTE) Name() string {
+	return m.name
+}
+
+// Option returns nil.  CTE has no table option.
+func (m *CTE) Option() *TableOption {
+	return nil
+}
+
+// Columns returns all columns.
+func (m *CTE) Columns() []Column {
+	if m.recursive {
+		return m.columns
+	}
+	if m.stat == nil {
+		return []Column{}
+	}
+
+	l := make([]Column, 0)
+	for _, col := range m.stat.selectColumns() {
+		if col == Star {
+			continue
+		}
+		typ := ColumnTypeAny
+		if cc := col.config(); cc != nil {
+			typ = cc.Type()
+		}
+		l = append(l, newColumnConfigImpl(col.column_name(), typ, nil).toColumn(m))
+	}
+	return l
+}
+
+// InnerJoin returns a joined table use with "INNER JOIN" clause.
+func (m *CTE) InnerJoin(right Table, on Condition) Table {
+	return &joinTable{
+		left:  m,
+		right: right,
+		typ:   inner_join,
+		on:    on,
+	}
+}
+
+// LeftOuterJoin returns a joined table use with "LEFT OUTER JOIN" clause.
+func (m *CTE) LeftOuterJoin(right Table, on Condition) Table {
+	return &joinTable{
+		left:  m,
+		right: right,
+		typ:   left_outer_join,
+		on:    on,
+	}
+}
+
+// RightOuterJoin returns a joined table use with "RIGHT OUTER JOIN" clause.
+func (m *CTE) RightOuterJoin(right Table, on Condition) Table {
+	return &joinTable{
+		left:  m,
+		right: right,
+		typ:   right_outer_join,
+		on:    on,
+	}
+}
+
+// FullOuterJoin returns a joined table use with "FULL OUTER JOIN" clause.
+func (m *CTE) FullOuterJoin(right Table, on Condition) Table {
+	return &joinTable{
+		left:  m,
+		right: right,
+		typ:   full_outer_join,
+		on:    on,
+	}
+}
+
+func (m *CTE) hasColumn(trg Column) bool {
+	var cimpl *columnImpl
+	switch t := trg.(type) {
+	case *columnImpl:
+		if trg == Star {
+			return true
+		}
+		cimpl = t
+	case *aliasColumn:
+		return m.hasColumn(t.column)
+	case compositeColumn:
+		for _, col := range t.columns() {
+			if !m.hasColumn(col) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+
+	if cimpl.table != m {
+		return false
+	}
+	for _, col := range m.Columns() {
+		if col.column_name() == cimpl.column_name() {
+			return true
+		}
+	}
+	return false
+}
+
+// WithClause represents a WITH clause.  Create a statement that uses the CTEs from this.
+type WithClause struct {
+	ctes    []*CTE
+	dialect Dialect
+
+	err error
+}
+
+// With returns new WITH clause with the ctes.
+// "WITH RECURSIVE" is used if any of the ctes is recursive.  RECURSIVE keyword is omitted if the dialect does not use it.
+func With(ctes ...*CTE) *WithClause {
+	if len(ctes) == 0 {
+		return &WithClause{
+			err: newError("WITH clause needs one or more CTEs."),
+		}
+	}
+	names := make(map[string]bool)
+	for _, cte := range ctes {
+		if cte == nil {
+			return &WithClause{
+				err: newError("CTE is nil."),
+			}
+		}
+		if names[cte.name] {
+			return &WithClause{
+				err: newError("CTE %s was duplicated.", cte.name),
+			}
+		}
+		names[cte.name] = true
+	}
+	return &WithClause{
+		ctes: ctes,
+	}
+}
+
+// Select returns new SELECT statement with the WITH clause and from as FROM clause.
+func (w *WithClause) Select(from Table) *SelectStatement {
+	s := Select(from)
+	s.with = w
+	s.dialect = w.dialect
+	return s
+}
+
+func (w *WithClause) serialize(bldr *builder) {
+	if w.err != nil {
+		bldr.SetError(w.err)
+		return
+	}
+
+	bldr.Append("WITH ")
+	for _, cte := range w.ctes {
+		if cte.recursive && bldr.dialect.QueryCapabilities()&QueryRecursiveKeyword != 0 {
+			bldr.Append("RECURSIVE ")
+			break
+		}
+	}
+
+	first := true
+	for _, cte := range w.ctes {
+		if first {
+			first = false
+		} else {
+			bldr.Append(", ")
+		}
+		cte.serializeDefinition(bldr)
+	}
+	bldr.Append(" ")
+}
@@ -0,0 +1,127 @@
+package sqlbuilder
+
+import (
+	"testing"
+)
+
+func TestCTE(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("parent_id", nil),
+		StringColumn("name", nil),
+	)
+	table2 := NewTable(
+		"TABLE_B",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("a_id", nil),
+	)
+
+	cte1 := NewCTE("CTE_1", Select(table1).
+		Columns(table1.C("id"), table1.C("name").As("label")).
+		Where(table1.C("parent_id").Eq(1)))
+	cte2 := NewCTE("CTE_2", Select(table2).
+		Columns(table2.C("a_id")).
+		Where(table2.C("id").Gt(2)))
+	joined := cte1.InnerJoin(cte2, cte1.C("id").Eq(cte2.C("a_id")))
+
+	tree := NewRecursiveCTE("TREE",
+		IntColumn("id", nil),
+		IntColumn("depth", nil),
+	)
+	tree.UnionAll(
+		Select(table1).
+			Columns(table1.C("id"), table1.C("parent_id")).
+			Where(table1.C("id").Eq(1)),
+		Select(table1.InnerJoin(tree, table1.C("parent_id").Eq(tree.C("id")))).
+			Columns(table1.C("id"), tree.C("depth")),
+	)
+
+	var cases = []statementTestCase{{
+		stmt: With(cte1).Select(cte1).
+			Columns(cte1.C("id"), cte1.C("label")).
+			Where(cte1.C("id").Lt(10)),
+		query: `WITH "CTE_1" AS ( SELECT "TABLE_A"."id", "TABLE_A"."name" AS "label" FROM "TABLE_A" WHERE "TABLE_A"."parent_id"=? ) ` +
+			`SELECT "CTE_1"."id", "CTE_1"."label" FROM "CTE_1" WHERE "CTE_1"."id"<?;`,
+		args:   []interface{}{int64(1), int64(10)},
+		errmsg: "",
+	}, {
+		stmt: With(cte1, cte2).Select(joined).
+			Columns(cte1.C("label")).
+			Where(cte2.C("a_id").Eq(3)),
+		query: `WITH "CTE_1" AS ( SELECT "TABLE_A"."id", "TABLE_A"."name" AS "label" FROM "TABLE_A" WHERE "TABLE_A"."parent_id"=? ), ` +
+			`"CTE_2" AS ( SELECT "TABLE_B"."a_id" FROM "TABLE_B" WHERE "TABLE_B"."id">? ) ` +
+			`SELECT "CTE_1"."label" FROM "CTE_1" INNER JOIN "CTE_2" ON "CTE_1"."id"="CTE_2"."a_id" WHERE "CTE_2"."a_id"=?;`,
+		args:   []interface{}{int64(1), int64(2), int64(3)},
+		errmsg: "",
+	}, {
+		stmt: With(tree).Select(tree),
+		query: `WITH RECURSIVE "TREE" ( "id", "depth" ) AS ( ` +
+			`SELECT "TABLE_A"."id", "TABLE_A"."parent_id" FROM "TABLE_A" WHERE "TABLE_A"."id"=? UNION ALL ` +
+			`SELECT "TABLE_A"."id", "TREE"."depth" FROM "TABLE_A" INNER JOIN "TREE" ON "TABLE_A"."parent_id"="TREE"."id" ) ` +
+			`SELECT * FROM "TREE";`,
+		args:   []interface{}{int64(1)},
+		errmsg: "",
+	}, {
+		stmt:   With(cte1).Select(cte1).Where(cte1.C("name").Eq("hoge")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column not found in FROM.",
+	}, {
+		stmt:   With(cte1).Select(cte1).Columns(table1.C("id"), cte1.C("id")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column not found in FROM.",
+	}, {
+		stmt:   With(cte1, cte1).Select(cte1),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: CTE CTE_1 was duplicated.",
+	}, {
+		stmt:   With().Select(table1),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: WITH clause needs one or more CTEs.",
+	}, {
+		stmt:   With(NewCTE("CTE_3", (*SelectStatement)(nil))).Select(table1),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: statement for CTE is nil.",
+	}, {
+		stmt:   With(NewCTE("CTE_3", (*CompoundStatement)(nil))).Select(table1),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: statement for CTE is nil.",
+	}, {
+		stmt:   With(NewCTE("CTE_3", Insert(table1).Values(1, 2, "hoge"))).Select(table1),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: CTE can use only SELECT statement.",
+	}, {
+		stmt: With(NewRecursiveCTE("CTE_5", IntColumn("id", nil)).UnionAll(
+			Select(table1).Columns(table1.C("id"), table1.C("parent_id")),
+			Select(table1).Columns(table1.C("id")),
+		)).Select(table1),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: CTE CTE_5 has 1 columns, but SELECT statement has 2 columns.",
+	}, {
+		stmt:   With(NewRecursiveCTE("CTE_4", IntColumn("id", nil))).Select(table1),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: CTE CTE_4 has no definition.",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
@@ -2,8 +2,11 @@ package sqlbuilder
 
 // DeleteStatement represents a DELETE statement.
 type DeleteStatement struct {
-	from  Table
-	where Condition
+	from      Table
+	where     Condition
+	returning returningColumnList
+
+	dialect Dialect
 
 	err error
 }
@@ -32,7 +35,7 @@ func (b *DeleteStatement) Where(cond Condition) *DeleteStatement {
 	}
 	for _, col := range cond.columns() {
 		if !b.from.hasColumn(col) {
-			b.err = newError("column not found in FROM")
+			b.err = newError("column not found in FROM.")
 			return b
 		}
 	}
@@ -40,9 +43,30 @@ func (b *DeleteStatement) Where(cond Condition) *DeleteStatement {
 	return b
 }
 
+// Returning sets RETURNING clause.  The columns are returned from deleted rows.
+func (b *DeleteStatement) Returning(columns ...Column) *DeleteStatement {
+	if b.err != nil {
+		return b
+	}
+	for _, col := range columns {
+		if !b.from.hasColumn(col) {
+			b.err = newError("column not found in FROM.")
+			return b
+		}
+	}
+	b.returning = returningColumnList(columns)
+	return b
+}
+
 // ToSql generates query string, placeholder arguments, and returns err on errors.
 func (b *DeleteStatement) ToSql() (query string, args []interface{}, err error) {
-	bldr := newBuilder()
+	return b.ToSqlWith(b.dialect)
+}
+
+// ToSqlWith generates query string, placeholder arguments with the d, and returns err on errors.
+// The default dialect is used if the d is nil.
+func (b *DeleteStatement) ToSqlWith(d Dialect) (query string, args []interface{}, err error) {
+	bldr := newBuilderWith(d)
 	defer func() {
 		query, args, err = bldr.Query(), bldr.Args(), bldr.Err()
 	}()
@@ -54,9 +78,19 @@ func (b *DeleteStatement) ToSql() (query string, args []interface{}, err error)
 	bldr.Append("DELETE FROM ")
 	bldr.AppendItem(b.from)
 
+	// columns in subqueries can refer the table.
+	bldr.pushScope(b.from)
+	defer bldr.popScope()
+
 	if b.where != nil {
 		bldr.Append(" WHERE ")
 		bldr.AppendItem(b.where)
 	}
+
+	// RETURNING
+	if len(b.returning) != 0 {
+		bldr.Append(" RETURNING ")
+		bldr.AppendItem(b.returning)
+	}
 	return
 }
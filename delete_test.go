@@ -28,6 +28,21 @@ func TestDelete(t *testing.T) {
 		query:  `DELETE FROM "TABLE_A" WHERE "TABLE_A"."id"=?;`,
 		args:   []interface{}{int64(1)},
 		errmsg: "",
+	}, {
+		stmt:   Delete(table1).Where(table1.C("id").Eq(1)).Returning(table1.C("id"), table1.C("test1")),
+		query:  `DELETE FROM "TABLE_A" WHERE "TABLE_A"."id"=? RETURNING "id", "test1";`,
+		args:   []interface{}{int64(1)},
+		errmsg: "",
+	}, {
+		stmt:   Delete(table1).Where(NotExists(Select(table2).Where(table2.C("id").Eq(table1.C("test1"))))),
+		query:  `DELETE FROM "TABLE_A" WHERE NOT EXISTS ( SELECT * FROM "TABLE_B" WHERE "TABLE_B"."id"="TABLE_A"."test1" );`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   Delete(table1).Returning(table2.C("id")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column not found in FROM.",
 	}, {
 		stmt:   Delete(nil).Where(table1.C("id").Eq(1)),
 		query:  ``,
@@ -47,3 +62,40 @@ func TestDelete(t *testing.T) {
 		}
 	}
 }
+
+func TestDeleteReturningNotSupported(t *testing.T) {
+	SetDialect(NoReturningTestDialect{})
+	defer SetDialect(TestDialect{})
+
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+	)
+
+	var cases = []statementTestCase{{
+		stmt:   Delete(table1).Returning(table1.C("id")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: dialect does not support RETURNING clause.",
+	}, {
+		stmt:   Insert(table1).Values(1).Returning(table1.C("id")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: dialect does not support RETURNING clause.",
+	}, {
+		stmt:   Update(table1).Set(table1.C("id"), 1).Returning(table1.C("id")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: dialect does not support RETURNING clause.",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
@@ -0,0 +1,587 @@
+package dialects
+
+import (
+	"reflect"
+	"testing"
+
+	sb "github.com/umisama/go-sqlbuilder"
+)
+
+type dialectTestCase struct {
+	stmt   sb.Statement
+	query  string
+	args   []interface{}
+	errmsg string
+}
+
+func runDialectTestCases(t *testing.T, d sb.Dialect, cases []dialectTestCase) {
+	for num, c := range cases {
+		query, args, err := c.stmt.ToSqlWith(d)
+		if len(c.errmsg) != 0 {
+			if err == nil {
+				t.Errorf("failed on %d: expected error %q, but got nil", num, c.errmsg)
+			} else if err.Error() != c.errmsg {
+				t.Errorf("failed on %d: expected error %q, but got %q", num, c.errmsg, err.Error())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("failed on %d: unexpected error %q", num, err.Error())
+			continue
+		}
+		if query != c.query {
+			t.Errorf("failed on %d: expected query %q, but got %q", num, c.query, query)
+		}
+		if !reflect.DeepEqual(args, c.args) {
+			t.Errorf("failed on %d: expected args %v, but got %v", num, c.args, args)
+		}
+	}
+}
+
+func TestUpdateCapabilities(t *testing.T) {
+	table1 := sb.NewTable(
+		"TABLE_A",
+		&sb.TableOption{},
+		sb.IntColumn("id", &sb.ColumnOption{
+			PrimaryKey: true,
+		}),
+		sb.IntColumn("value", nil),
+	)
+	update := func() *sb.UpdateStatement {
+		return sb.Update(table1).Set(table1.C("value"), 1)
+	}
+
+	runDialectTestCases(t, MySql{}, []dialectTestCase{{
+		stmt:  update().OrderBy(false, table1.C("id")).Limit(10),
+		query: "UPDATE `TABLE_A` SET `value`=? ORDER BY `TABLE_A`.`id` ASC LIMIT ?;",
+		args:  []interface{}{int64(1), 10},
+	}, {
+		stmt:   update().Limit(10).Offset(5),
+		errmsg: "sqlbuilder: dialect does not support OFFSET clause in UPDATE statement.",
+	}})
+
+	for _, d := range []sb.Dialect{Postgresql{}, Sqlite{}, Mssql{}} {
+		runDialectTestCases(t, d, []dialectTestCase{{
+			stmt:   update().Limit(10),
+			errmsg: "sqlbuilder: dialect does not support LIMIT clause in UPDATE statement.",
+		}, {
+			stmt:   update().OrderBy(false, table1.C("id")),
+			errmsg: "sqlbuilder: dialect does not support ORDER BY clause in UPDATE statement.",
+		}})
+	}
+}
+
+func TestQuoteField(t *testing.T) {
+	cases := []struct {
+		dialect sb.Dialect
+		field   interface{}
+		expect  string
+	}{
+		{MySql{}, "a`b", "`a``b`"},
+		{Postgresql{}, `a"b`, `"a""b"`},
+		{Sqlite{}, `a"b`, `"a""b"`},
+		{Mssql{}, "a]b", "[a]]b]"},
+		{Oracle{}, `a"b`, `"a""b"`},
+		{Postgresql{}, true, "TRUE"},
+		{Mssql{}, true, "1"},
+		{Oracle{}, nil, "NULL"},
+	}
+	for num, c := range cases {
+		if got := c.dialect.QuoteField(c.field); got != c.expect {
+			t.Errorf("failed on %d: expected %q, but got %q", num, c.expect, got)
+		}
+	}
+}
+
+func TestForeignKeyActionToString(t *testing.T) {
+	cases := []struct {
+		dialect sb.Dialect
+		fk      sb.ForeignKey
+		expect  string
+		errmsg  string
+	}{
+		{MySql{}, sb.ForeignKey{OnDelete: sb.ForeignKeyCascade, OnUpdate: sb.ForeignKeyRestrict}, "ON DELETE CASCADE ON UPDATE RESTRICT", ""},
+		{Postgresql{}, sb.ForeignKey{OnDelete: sb.ForeignKeySetNull}, "ON DELETE SET NULL", ""},
+		{Sqlite{}, sb.ForeignKey{}, "", ""},
+		{Mssql{}, sb.ForeignKey{OnUpdate: sb.ForeignKeyCascade}, "ON UPDATE CASCADE", ""},
+		{Mssql{}, sb.ForeignKey{OnDelete: sb.ForeignKeyRestrict}, "", "dialects: mssql does not support RESTRICT, use NO ACTION"},
+		{Oracle{}, sb.ForeignKey{OnDelete: sb.ForeignKeyCascade}, "ON DELETE CASCADE", ""},
+		{Oracle{}, sb.ForeignKey{OnUpdate: sb.ForeignKeyCascade}, "", "dialects: oracle does not support ON UPDATE clause"},
+	}
+	for num, c := range cases {
+		got, err := c.dialect.ForeignKeyActionToString(&c.fk)
+		if len(c.errmsg) != 0 {
+			if err == nil || err.Error() != c.errmsg {
+				t.Errorf("failed on %d: expected error %q, but got %v", num, c.errmsg, err)
+			}
+			continue
+		}
+		if err != nil || got != c.expect {
+			t.Errorf("failed on %d: expected %q, but got %q %v", num, c.expect, got, err)
+		}
+	}
+}
+
+func TestTableConstraints(t *testing.T) {
+	table1 := sb.NewTable(
+		"TABLE_A",
+		&sb.TableOption{
+			PrimaryKey: []string{"id", "sub_id"},
+			UniqueKeys: []sb.UniqueKey{{
+				Name:    "U_NAME",
+				Columns: []string{"name"},
+			}},
+		},
+		sb.IntColumn("id", nil),
+		sb.IntColumn("sub_id", nil),
+		sb.StringColumn("name", &sb.ColumnOption{
+			Size:    255,
+			NotNull: true,
+		}),
+	)
+	table1.Option().Checks = []sb.Check{{
+		Name:      "C_NAME",
+		Condition: table1.C("name").NotEq(`a\'b`),
+	}}
+
+	runDialectTestCases(t, MySql{}, []dialectTestCase{{
+		stmt:  sb.CreateTable(table1),
+		query: "CREATE TABLE `TABLE_A` ( `id` INTEGER, `sub_id` INTEGER, `name` VARCHAR(255) NOT NULL, PRIMARY KEY(`id`, `sub_id`), CONSTRAINT `U_NAME` UNIQUE(`name`), CONSTRAINT `C_NAME` CHECK ( `name`<>'a\\\\''b' ) );",
+		args:  []interface{}{},
+	}})
+	runDialectTestCases(t, Postgresql{}, []dialectTestCase{{
+		stmt:  sb.CreateTable(table1),
+		query: `CREATE TABLE "TABLE_A" ( "id" BIGINT DEFAULT NULL, "sub_id" BIGINT DEFAULT NULL, "name" VARCHAR(255) NOT NULL DEFAULT NULL, PRIMARY KEY("id", "sub_id"), CONSTRAINT "U_NAME" UNIQUE("name"), CONSTRAINT "C_NAME" CHECK ( "name"<>'a\''b' ) );`,
+		args:  []interface{}{},
+	}})
+	runDialectTestCases(t, Sqlite{}, []dialectTestCase{{
+		stmt:  sb.CreateTable(table1),
+		query: `CREATE TABLE "TABLE_A" ( "id" INTEGER DEFAULT NULL, "sub_id" INTEGER DEFAULT NULL, "name" TEXT NOT NULL DEFAULT NULL, PRIMARY KEY("id", "sub_id"), CONSTRAINT "U_NAME" UNIQUE("name"), CONSTRAINT "C_NAME" CHECK ( "name"<>'a\''b' ) );`,
+		args:  []interface{}{},
+	}})
+}
+
+func TestIndexCapabilities(t *testing.T) {
+	table1 := sb.NewTable(
+		"TABLE_A",
+		&sb.TableOption{},
+		sb.IntColumn("id", nil),
+		sb.StringColumn("name", nil),
+	)
+
+	runDialectTestCases(t, MySql{}, []dialectTestCase{{
+		stmt:  sb.CreateIndex(table1).Name("I_NAME").Columns(sb.Lower(table1.C("name"))),
+		query: "CREATE INDEX `I_NAME` ON `TABLE_A` ( ( LOWER(`name`) ) );",
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.CreateIndex(table1).Name("I_NAME").Columns(table1.C("name")).Where(table1.C("id").Gt(0)),
+		errmsg: "sqlbuilder: dialect does not support WHERE clause in CREATE INDEX statement.",
+	}, {
+		stmt:  sb.DropIndex(table1, "I_NAME"),
+		query: "DROP INDEX `I_NAME` ON `TABLE_A`;",
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.DropIndex(table1, "I_NAME").IfExists(),
+		errmsg: "sqlbuilder: dialect does not support IF EXISTS clause in DROP INDEX statement.",
+	}})
+	runDialectTestCases(t, Postgresql{}, []dialectTestCase{{
+		stmt:  sb.CreateIndex(table1).Name("I_NAME").Using("gin").Columns(table1.C("name")).Where(table1.C("id").Gt(0)),
+		query: `CREATE INDEX "I_NAME" ON "TABLE_A" USING gin ( "name" ) WHERE "id">0;`,
+		args:  []interface{}{},
+	}, {
+		stmt:  sb.DropIndex(table1, "I_NAME").IfExists(),
+		query: `DROP INDEX IF EXISTS "I_NAME";`,
+		args:  []interface{}{},
+	}})
+	runDialectTestCases(t, Sqlite{}, []dialectTestCase{{
+		stmt:   sb.CreateIndex(table1).Name("I_NAME").Using("btree").Columns(table1.C("name")),
+		errmsg: "sqlbuilder: dialect does not support USING clause in CREATE INDEX statement.",
+	}, {
+		stmt:  sb.DropIndex(table1, "I_NAME").IfExists(),
+		query: `DROP INDEX IF EXISTS "I_NAME";`,
+		args:  []interface{}{},
+	}})
+	runDialectTestCases(t, Mssql{}, []dialectTestCase{{
+		stmt:   sb.CreateIndex(table1).Name("I_NAME").Columns(sb.Lower(table1.C("name"))),
+		errmsg: "sqlbuilder: dialect does not support expression index.",
+	}, {
+		stmt:  sb.DropIndex(table1, "I_NAME"),
+		query: "DROP INDEX [I_NAME] ON [TABLE_A];",
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.DropIndex(table1, "I_NAME").IfExists(),
+		errmsg: "sqlbuilder: dialect does not support IF EXISTS clause in DROP INDEX statement.",
+	}})
+}
+
+func TestDropTableAndTruncate(t *testing.T) {
+	table1 := sb.NewTable(
+		"TABLE_A",
+		&sb.TableOption{},
+		sb.IntColumn("id", nil),
+	)
+	table2 := sb.NewTable(
+		"TABLE_B",
+		&sb.TableOption{},
+		sb.IntColumn("id", nil),
+	)
+
+	runDialectTestCases(t, MySql{}, []dialectTestCase{{
+		stmt:  sb.DropTable(table1, table2).IfExists(),
+		query: "DROP TABLE IF EXISTS `TABLE_A`, `TABLE_B`;",
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.DropTable(table1).Cascade(),
+		errmsg: "dialects: mysql does not support CASCADE in DROP TABLE",
+	}, {
+		stmt:  sb.Truncate(table1),
+		query: "TRUNCATE TABLE `TABLE_A`;",
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.Truncate(table1, table2),
+		errmsg: "dialects: mysql can truncate only one table at once",
+	}})
+	runDialectTestCases(t, Postgresql{}, []dialectTestCase{{
+		stmt:  sb.DropTable(table1, table2).IfExists().Cascade(),
+		query: `DROP TABLE IF EXISTS "TABLE_A", "TABLE_B" CASCADE;`,
+		args:  []interface{}{},
+	}, {
+		stmt:  sb.Truncate(table1, table2).RestartIdentity().Cascade(),
+		query: `TRUNCATE TABLE "TABLE_A", "TABLE_B" RESTART IDENTITY CASCADE;`,
+		args:  []interface{}{},
+	}})
+	runDialectTestCases(t, Sqlite{}, []dialectTestCase{{
+		stmt:  sb.DropTable(table1).IfExists(),
+		query: `DROP TABLE IF EXISTS "TABLE_A";`,
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.DropTable(table1, table2),
+		errmsg: "dialects: sqlite can drop only one table at once",
+	}, {
+		stmt:  sb.Truncate(table1),
+		query: `DELETE FROM "TABLE_A";`,
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.Truncate(table1).RestartIdentity(),
+		errmsg: "dialects: sqlite does not support RESTART IDENTITY and CASCADE in TRUNCATE TABLE",
+	}})
+	runDialectTestCases(t, Mssql{}, []dialectTestCase{{
+		stmt:  sb.DropTable(table1, table2),
+		query: "DROP TABLE [TABLE_A], [TABLE_B];",
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.DropTable(table1).IfExists(),
+		errmsg: "dialects: mssql does not support IF EXISTS in DROP TABLE",
+	}, {
+		stmt:  sb.Truncate(table1),
+		query: "TRUNCATE TABLE [TABLE_A];",
+		args:  []interface{}{},
+	}})
+	runDialectTestCases(t, Oracle{}, []dialectTestCase{{
+		stmt:  sb.DropTable(table1).Cascade(),
+		query: `DROP TABLE "TABLE_A" CASCADE CONSTRAINTS`,
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.DropTable(table1).IfExists(),
+		errmsg: "dialects: oracle does not support IF EXISTS in DROP TABLE",
+	}, {
+		stmt:  sb.Truncate(table1).Cascade(),
+		query: `TRUNCATE TABLE "TABLE_A" CASCADE`,
+		args:  []interface{}{},
+	}})
+}
+
+func TestAlterTableCapabilities(t *testing.T) {
+	table1 := sb.NewTable(
+		"TABLE_A",
+		&sb.TableOption{},
+		sb.IntColumn("id", &sb.ColumnOption{
+			PrimaryKey: true,
+		}),
+		sb.IntColumn("value", nil),
+	)
+	table2 := sb.NewTable(
+		"TABLE_B",
+		&sb.TableOption{},
+		sb.IntColumn("id", &sb.ColumnOption{
+			PrimaryKey: true,
+		}),
+	)
+	table3 := sb.NewTable(
+		"TABLE_D",
+		&sb.TableOption{Schema: "db1"},
+		sb.IntColumn("id", &sb.ColumnOption{
+			PrimaryKey:    true,
+			AutoIncrement: true,
+		}),
+		sb.IntColumn("value", &sb.ColumnOption{
+			ForeignKey: &sb.ForeignKey{References: []sb.Column{table2.C("id")}},
+		}),
+	)
+	fk := sb.ForeignKey{
+		Columns:    []string{"value"},
+		References: []sb.Column{table2.C("id")},
+	}
+
+	runDialectTestCases(t, MySql{}, []dialectTestCase{{
+		stmt:  sb.AlterTable(table1).ChangeColumnFirst(table1.C("value"), sb.IntColumn("value2", nil)).RenameTo("TABLE_C"),
+		query: "ALTER TABLE `TABLE_A` CHANGE COLUMN `value` `value2` INTEGER FIRST, RENAME TO `TABLE_C`;",
+		args:  []interface{}{},
+	}, {
+		stmt:  sb.AlterTable(table3).RenameTo("TABLE_E"),
+		query: "ALTER TABLE `db1`.`TABLE_D` RENAME TO `db1`.`TABLE_E`;",
+		args:  []interface{}{},
+	}})
+	runDialectTestCases(t, Postgresql{}, []dialectTestCase{{
+		stmt: sb.AlterTable(table1).
+			ChangeColumn(table1.C("value"), sb.IntColumn("value2", &sb.ColumnOption{NotNull: true, Default: 1})).
+			AddColumn(sb.StringColumn("name", &sb.ColumnOption{Size: 10})).
+			RenameTo("TABLE_C"),
+		errmsg: "sqlbuilder: dialect needs 3 ALTER TABLE statements, use ToSqls().",
+	}, {
+		stmt:  sb.AlterTable(table1).ChangeColumn(table1.C("value"), sb.FloatColumn("value", nil)),
+		query: `ALTER TABLE "TABLE_A" ALTER COLUMN "value" TYPE REAL;`,
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.AlterTable(table1).ChangeColumn(table1.C("value"), sb.IntColumn("value", nil)),
+		errmsg: "sqlbuilder: ALTER TABLE statement needs one or more actions.",
+	}, {
+		stmt:   sb.AlterTable(table3).ChangeColumn(table3.C("id"), sb.IntColumn("id", &sb.ColumnOption{PrimaryKey: true, AutoIncrement: true})),
+		errmsg: "sqlbuilder: dialect can not change auto increment column in ALTER TABLE statement.",
+	}, {
+		stmt: sb.AlterTable(table3).ChangeColumn(table3.C("value"), sb.IntColumn("value", &sb.ColumnOption{
+			NotNull:    true,
+			ForeignKey: &sb.ForeignKey{References: []sb.Column{table2.C("id")}},
+		})),
+		query: `ALTER TABLE "db1"."TABLE_D" ALTER COLUMN "value" SET NOT NULL;`,
+		args:  []interface{}{},
+	}, {
+		stmt:  sb.AlterTable(table3).RenameTo("TABLE_E"),
+		query: `ALTER TABLE "db1"."TABLE_D" RENAME TO "TABLE_E";`,
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.AlterTable(table1).ChangeColumn(table1.C("value"), sb.IntColumn("value", &sb.ColumnOption{Unique: true})),
+		errmsg: "sqlbuilder: dialect can not change constraints of column in ALTER TABLE statement.",
+	}, {
+		stmt:   sb.AlterTable(table1).AddColumnFirst(sb.IntColumn("value2", nil)),
+		errmsg: "sqlbuilder: dialect does not support FIRST and AFTER clause in ALTER TABLE statement.",
+	}})
+	runDialectTestCases(t, Sqlite{}, []dialectTestCase{{
+		stmt:  sb.AlterTable(table1).AddColumn(sb.IntColumn("value2", nil)),
+		query: `ALTER TABLE "TABLE_A" ADD COLUMN "value2" INTEGER DEFAULT NULL;`,
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.AlterTable(table1).AddColumn(sb.IntColumn("value2", nil)).DropColumn(table1.C("value")).RenameColumn(table1.C("id"), "id2"),
+		errmsg: "sqlbuilder: dialect needs 3 ALTER TABLE statements, use ToSqls().",
+	}, {
+		stmt:   sb.AlterTable(table1).ChangeColumn(table1.C("value"), sb.IntColumn("value2", nil)),
+		errmsg: "sqlbuilder: dialect does not support changing column in ALTER TABLE statement.",
+	}, {
+		stmt:   sb.AlterTable(table1).AddForeignKey(fk),
+		errmsg: "sqlbuilder: dialect does not support adding constraint in ALTER TABLE statement.",
+	}})
+	runDialectTestCases(t, Mssql{}, []dialectTestCase{{
+		stmt:  sb.AlterTable(table1).AddForeignKey(fk),
+		query: "ALTER TABLE [TABLE_A] ADD FOREIGN KEY ( [value] ) REFERENCES [TABLE_B] ( [id] );",
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.AlterTable(table1).AddColumn(sb.IntColumn("value2", nil)).AddForeignKey(fk),
+		errmsg: "sqlbuilder: dialect needs 2 ALTER TABLE statements, use ToSqls().",
+	}, {
+		stmt:   sb.AlterTable(table1).RenameTo("TABLE_C"),
+		errmsg: "sqlbuilder: dialect does not support RENAME TO clause in ALTER TABLE statement.",
+	}})
+	runDialectTestCases(t, Oracle{}, []dialectTestCase{{
+		stmt:  sb.AlterTable(table1).RenameColumn(table1.C("value"), "value2"),
+		query: `ALTER TABLE "TABLE_A" RENAME COLUMN "value" TO "value2"`,
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.AlterTable(table1).DropColumn(table1.C("value")).RenameTo("TABLE_C"),
+		errmsg: "sqlbuilder: dialect needs 2 ALTER TABLE statements, use ToSqls().",
+	}})
+}
+
+func TestAlterTableToSqls(t *testing.T) {
+	table1 := sb.NewTable(
+		"TABLE_A",
+		&sb.TableOption{},
+		sb.IntColumn("id", &sb.ColumnOption{
+			PrimaryKey: true,
+		}),
+		sb.IntColumn("value", nil),
+	)
+	table2 := sb.NewTable(
+		"TABLE_B",
+		&sb.TableOption{},
+		sb.IntColumn("id", &sb.ColumnOption{
+			PrimaryKey: true,
+		}),
+	)
+	fk := sb.ForeignKey{
+		Columns:    []string{"value"},
+		References: []sb.Column{table2.C("id")},
+	}
+
+	cases := []struct {
+		dialect sb.Dialect
+		stmt    *sb.AlterTableStatement
+		queries []string
+		errmsg  string
+	}{{
+		MySql{},
+		sb.AlterTable(table1).DropColumn(table1.C("value")).RenameTo("TABLE_C"),
+		[]string{"ALTER TABLE `TABLE_A` DROP COLUMN `value`, RENAME TO `TABLE_C`;"},
+		"",
+	}, {
+		Postgresql{},
+		sb.AlterTable(table1).
+			ChangeColumn(table1.C("value"), sb.IntColumn("value2", &sb.ColumnOption{NotNull: true, Default: 1})).
+			AddColumn(sb.StringColumn("name", &sb.ColumnOption{Size: 10})).
+			RenameTo("TABLE_C"),
+		[]string{
+			`ALTER TABLE "TABLE_A" ADD COLUMN "name" VARCHAR(10) DEFAULT NULL, ALTER COLUMN "value" SET DEFAULT 1, ALTER COLUMN "value" SET NOT NULL;`,
+			`ALTER TABLE "TABLE_A" RENAME COLUMN "value" TO "value2";`,
+			`ALTER TABLE "TABLE_A" RENAME TO "TABLE_C";`,
+		},
+		"",
+	}, {
+		Sqlite{},
+		sb.AlterTable(table1).AddColumn(sb.IntColumn("value2", nil)).DropColumn(table1.C("value")).RenameColumn(table1.C("id"), "id2"),
+		[]string{
+			`ALTER TABLE "TABLE_A" ADD COLUMN "value2" INTEGER DEFAULT NULL;`,
+			`ALTER TABLE "TABLE_A" DROP COLUMN "value";`,
+			`ALTER TABLE "TABLE_A" RENAME COLUMN "id" TO "id2";`,
+		},
+		"",
+	}, {
+		Mssql{},
+		sb.AlterTable(table1).AddColumn(sb.IntColumn("value2", nil)).AddForeignKey(fk),
+		[]string{
+			"ALTER TABLE [TABLE_A] ADD [value2] BIGINT DEFAULT NULL;",
+			"ALTER TABLE [TABLE_A] ADD FOREIGN KEY ( [value] ) REFERENCES [TABLE_B] ( [id] );",
+		},
+		"",
+	}, {
+		Oracle{},
+		sb.AlterTable(table1).DropColumn(table1.C("value")).RenameTo("TABLE_C"),
+		[]string{
+			`ALTER TABLE "TABLE_A" DROP COLUMN "value"`,
+			`ALTER TABLE "TABLE_A" RENAME TO "TABLE_C"`,
+		},
+		"",
+	}, {
+		Mssql{},
+		sb.AlterTable(table1).AddColumn(sb.IntColumn("value2", nil)).RenameTo("TABLE_C"),
+		nil,
+		"sqlbuilder: dialect does not support RENAME TO clause in ALTER TABLE statement.",
+	}}
+
+	for num, c := range cases {
+		queries, args, err := c.stmt.ToSqlsWith(c.dialect)
+		if len(c.errmsg) != 0 {
+			if err == nil || err.Error() != c.errmsg {
+				t.Errorf("failed on %d: expected error %q, but got %v", num, c.errmsg, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(queries, c.queries) {
+			t.Errorf("failed on %d: expected %q, but got %q %v", num, c.queries, queries, err)
+		}
+		if len(args) != len(queries) {
+			t.Errorf("failed on %d: expected %d args, but got %d", num, len(queries), len(args))
+		}
+	}
+}
+
+func TestQueryCapabilities(t *testing.T) {
+	table1 := sb.NewTable(
+		"TABLE_A",
+		&sb.TableOption{},
+		sb.IntColumn("id", nil),
+		sb.IntColumn("parent_id", nil),
+	)
+	tree := sb.NewRecursiveCTE("TREE", sb.IntColumn("id", nil))
+	tree.UnionAll(
+		sb.Select(table1).Columns(table1.C("id")).Where(table1.C("id").Eq(1)),
+		sb.Select(table1.InnerJoin(tree, table1.C("parent_id").Eq(tree.C("id")))).Columns(table1.C("id")),
+	)
+
+	runDialectTestCases(t, Postgresql{}, []dialectTestCase{{
+		stmt: sb.With(tree).Select(tree),
+		query: `WITH RECURSIVE "TREE" ( "id" ) AS ( SELECT "TABLE_A"."id" FROM "TABLE_A" WHERE "TABLE_A"."id"=$1 UNION ALL ` +
+			`SELECT "TABLE_A"."id" FROM "TABLE_A" INNER JOIN "TREE" ON "TABLE_A"."parent_id"="TREE"."id" ) SELECT * FROM "TREE";`,
+		args: []interface{}{int64(1)},
+	}})
+	runDialectTestCases(t, Mssql{}, []dialectTestCase{{
+		stmt: sb.With(tree).Select(tree),
+		query: `WITH [TREE] ( [id] ) AS ( SELECT [TABLE_A].[id] FROM [TABLE_A] WHERE [TABLE_A].[id]=@p1 UNION ALL ` +
+			`SELECT [TABLE_A].[id] FROM [TABLE_A] INNER JOIN [TREE] ON [TABLE_A].[parent_id]=[TREE].[id] ) SELECT * FROM [TREE];`,
+		args: []interface{}{int64(1)},
+	}})
+}
+
+func TestLockOptionToString(t *testing.T) {
+	cases := []struct {
+		dialect sb.Dialect
+		lo      sb.LockOption
+		expect  string
+		errmsg  string
+	}{
+		{MySql{}, sb.LockOption{Mode: sb.LockForUpdate, Of: []string{"TABLE_A"}, SkipLocked: true}, "FOR UPDATE OF `TABLE_A` SKIP LOCKED", ""},
+		{MySql{}, sb.LockOption{Mode: sb.LockForShare, NoWait: true}, "FOR SHARE NOWAIT", ""},
+		{MySql{}, sb.LockOption{Mode: sb.LockMode(100)}, "", "dialects: unknown lock mode"},
+		{Postgresql{}, sb.LockOption{Mode: sb.LockForUpdate, Of: []string{"TABLE_A", "TABLE_B"}}, `FOR UPDATE OF "TABLE_A", "TABLE_B"`, ""},
+		{Postgresql{}, sb.LockOption{Mode: sb.LockForShare, NoWait: true, SkipLocked: true}, "", "dialects: NOWAIT and SKIP LOCKED can not be used together"},
+		{Postgresql{}, sb.LockOption{Mode: sb.LockMode(100)}, "", "dialects: unknown lock mode"},
+		{Sqlite{}, sb.LockOption{Mode: sb.LockForUpdate}, "", "dialects: sqlite does not support row locking clause(FOR UPDATE, FOR SHARE, NOWAIT, SKIP LOCKED)"},
+		{Oracle{}, sb.LockOption{Mode: sb.LockForUpdate, NoWait: true}, "FOR UPDATE NOWAIT", ""},
+		{Oracle{}, sb.LockOption{Mode: sb.LockForShare}, "", "dialects: oracle does not support FOR SHARE"},
+		{Oracle{}, sb.LockOption{Mode: sb.LockMode(100)}, "", "dialects: unknown lock mode"},
+	}
+	for num, c := range cases {
+		got, err := c.dialect.LockOptionToString(&c.lo)
+		if len(c.errmsg) != 0 {
+			if err == nil || err.Error() != c.errmsg {
+				t.Errorf("failed on %d: expected error %q, but got %v", num, c.errmsg, err)
+			}
+			continue
+		}
+		if err != nil || got != c.expect {
+			t.Errorf("failed on %d: expected %q, but got %q %v", num, c.expect, got, err)
+		}
+	}
+}
+
+func TestUpsertSyntax(t *testing.T) {
+	cases := []struct {
+		dialect sb.Dialect
+		uo      sb.UpsertOption
+		expect  sb.UpsertSyntax
+		errmsg  string
+	}{
+		{MySql{}, sb.UpsertOption{DoUpdate: true}, sb.UpsertOnDuplicateKeyUpdate, ""},
+		{MySql{}, sb.UpsertOption{DoNothing: true}, sb.UpsertInsertIgnore, ""},
+		{MySql{}, sb.UpsertOption{Replace: true}, sb.UpsertReplace, ""},
+		{MySql{}, sb.UpsertOption{Target: []string{"id"}, DoNothing: true}, 0, "dialects: mysql does not support conflict target"},
+		{MySql{}, sb.UpsertOption{DoUpdate: true, Where: true}, 0, "dialects: mysql does not support WHERE clause in ON DUPLICATE KEY UPDATE"},
+		{Postgresql{}, sb.UpsertOption{Target: []string{"id"}, DoUpdate: true, Where: true}, sb.UpsertOnConflict, ""},
+		{Postgresql{}, sb.UpsertOption{DoNothing: true}, sb.UpsertOnConflict, ""},
+		{Postgresql{}, sb.UpsertOption{DoUpdate: true}, 0, "dialects: ON CONFLICT DO UPDATE needs conflict target"},
+		{Postgresql{}, sb.UpsertOption{Replace: true}, 0, "dialects: postgresql does not support INSERT OR REPLACE"},
+		{Sqlite{}, sb.UpsertOption{Target: []string{"id"}, DoUpdate: true}, sb.UpsertOnConflict, ""},
+		{Sqlite{}, sb.UpsertOption{Replace: true}, sb.UpsertInsertOrReplace, ""},
+		{Sqlite{}, sb.UpsertOption{DoUpdate: true}, 0, "dialects: ON CONFLICT DO UPDATE needs conflict target"},
+	}
+	for num, c := range cases {
+		got, err := c.dialect.UpsertSyntax(&c.uo)
+		if len(c.errmsg) != 0 {
+			if err == nil || err.Error() != c.errmsg {
+				t.Errorf("failed on %d: expected error %q, but got %v", num, c.errmsg, err)
+			}
+			continue
+		}
+		if err != nil || got != c.expect {
+			t.Errorf("failed on %d: expected %v, but got %v %v", num, c.expect, got, err)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package dialects
+
+import (
+	"errors"
+	"fmt"
+	sb "github.com/umisama/go-sqlbuilder"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Mssql is a dialect for Microsoft SQL Server 2012 or later.
+type Mssql struct{}
+
+func (m Mssql) QuerySuffix() string {
+	return ";"
+}
+
+func (m Mssql) BindVar(i int) string {
+	return "@p" + strconv.Itoa(i)
+}
+
+func (m Mssql) quoteField(field interface{}) (string, bool) {
+	str := ""
+	bracket := true
+	switch t := field.(type) {
+	case string:
+		str = t
+	case []byte:
+		str = string(t)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		str = fmt.Sprint(field)
+	case float32, float64:
+		str = fmt.Sprint(field)
+	case time.Time:
+		str = t.Format("2006-01-02 15:04:05")
+	case bool:
+		// SQL Server has no boolean literal.
+		if t {
+			str = "1"
+		} else {
+			str = "0"
+		}
+		bracket = false
+	case nil:
+		str = "NULL"
+		bracket = false
+	}
+	return str, bracket
+}
+
+// MaxPlaceholders returns 2100, the maximum number of parameters in a request.
+func (m Mssql) MaxPlaceholders() int {
+	return 2100
+}
+
+func (m Mssql) QuoteField(field interface{}) string {
+	str, bracket := m.quoteField(field)
+	if bracket {
+		str = "[" + strings.Replace(str, "]", "]]", -1) + "]"
+	}
+	return str
+}
+
+func (m Mssql) ColumnTypeToString(cc sb.ColumnConfig) (string, error) {
+	if cc.Option().SqlType != "" {
+		return cc.Option().SqlType, nil
+	}
+
+	typ := ""
+	switch cc.Type() {
+	case sb.ColumnTypeInt:
+		typ = "BIGINT"
+	case sb.ColumnTypeString:
+		if cc.Option().Size == 0 {
+			typ = "NVARCHAR(MAX)"
+		} else {
+			typ = fmt.Sprintf("NVARCHAR(%d)", cc.Option().Size)
+		}
+	case sb.ColumnTypeDate:
+		typ = "DATETIME2"
+	case sb.ColumnTypeFloat:
+		typ = "FLOAT"
+	case sb.ColumnTypeBool:
+		typ = "BIT"
+	case sb.ColumnTypeBytes:
+		typ = "VARBINARY(MAX)"
+	}
+
+	if typ == "" {
+		return "", errors.New("dialects: unknown column type")
+	} else {
+		return typ, nil
+	}
+}
+
+// ColumnOptionToString returns options of the column.  IDENTITY column can not have DEFAULT.
+func (m Mssql) ColumnOptionToString(co *sb.ColumnOption) (string, error) {
+	if co.AutoIncrement && co.Default != nil {
+		return "", errors.New("dialects: mssql does not support DEFAULT for IDENTITY column")
+	}
+
+	opt := ""
+	if co.AutoIncrement {
+		opt = str_append(opt, "IDENTITY(1,1)")
+	}
+	if co.PrimaryKey {
+		opt = str_append(opt, "PRIMARY KEY")
+	}
+	if co.NotNull {
+		opt = str_append(opt, "NOT NULL")
+	}
+	if co.Unique {
+		opt = str_append(opt, "UNIQUE")
+	}
+	if co.Default == nil {
+		if !co.PrimaryKey && !co.AutoIncrement {
+			opt = str_append(opt, "DEFAULT NULL")
+		}
+	} else {
+		str, bracket := m.quoteField(co.Default)
+		if bracket {
+			str = "'" + strings.Replace(str, "'", "''", -1) + "'"
+		}
+		opt = str_append(opt, "DEFAULT "+str)
+	}
+
+	return opt, nil
+}
+
+func (m Mssql) TableOptionToString(to *sb.TableOption) (string, error) {
+	return table_constraints(to, m.QuoteField), nil
+}
+
+// LockOptionToString returns error.  SQL Server uses table hints(ex: WITH (UPDLOCK)) instead of FOR UPDATE.
+func (m Mssql) LockOptionToString(lo *sb.LockOption) (string, error) {
+	return "", errors.New("dialects: mssql does not support row locking clause(FOR UPDATE, FOR SHARE, NOWAIT, SKIP LOCKED)")
+}
+
+func (m Mssql) UpsertSyntax(uo *sb.UpsertOption) (sb.UpsertSyntax, error) {
+	return sb.UpsertOnConflict, errors.New("dialects: mssql does not support upsert")
+}
+
+// SupportsReturning returns false.  SQL Server uses OUTPUT clause instead of RETURNING.
+func (m Mssql) SupportsReturning() bool {
+	return false
+}
+
+func (m Mssql) ConcatOperator() string {
+	return "+"
+}
+
+func (m Mssql) CastTypeToString(typ sb.ColumnType) (string, error) {
+	switch typ {
+	case sb.ColumnTypeInt:
+		return "BIGINT", nil
+	case sb.ColumnTypeString:
+		return "NVARCHAR(MAX)", nil
+	case sb.ColumnTypeDate:
+		return "DATETIME2", nil
+	case sb.ColumnTypeFloat:
+		return "FLOAT", nil
+	case sb.ColumnTypeBool:
+		return "BIT", nil
+	case sb.ColumnTypeBytes:
+		return "VARBINARY(MAX)", nil
+	}
+	return "", errors.New("dialects: unknown column type")
+}
+
+// PagingSyntax returns PagingOffsetFetch.  OFFSET/FETCH can not be used without ORDER BY clause on SQL Server.
+func (m Mssql) PagingSyntax(po *sb.PagingOption) (sb.PagingSyntax, error) {
+	if !po.OrderBy {
+		return sb.PagingOffsetFetch, errors.New("dialects: mssql needs ORDER BY clause for OFFSET and FETCH")
+	}
+	return sb.PagingOffsetFetch, nil
+}
+
+// UpdateCapabilities returns none.  SQL Server uses UPDATE TOP (n) instead of LIMIT.
+func (m Mssql) UpdateCapabilities() sb.UpdateCapability {
+	return 0
+}
+
+func (m Mssql) MaxIdentifierLength() int {
+	return 128
+}
+
+func (m Mssql) ForeignKeyActionToString(fk *sb.ForeignKey) (string, error) {
+	if fk.OnDelete == sb.ForeignKeyRestrict || fk.OnUpdate == sb.ForeignKeyRestrict {
+		return "", errors.New("dialects: mssql does not support RESTRICT, use NO ACTION")
+	}
+	return foreign_key_actions(fk), nil
+}
+
+func (m Mssql) LiteralToString(val interface{}) (string, error) {
+	return literal_to_string(val, "1", "0", escape_quote)
+}
+
+// IndexCapabilities returns filtered index and DROP INDEX ... ON table.  Use computed column instead of expression index.
+// DROP INDEX IF EXISTS is not used, it is available on SQL Server 2016 or later.
+func (m Mssql) IndexCapabilities() sb.IndexCapability {
+	return sb.IndexPartial | sb.IndexDropOnTable
+}
+
+// DropTableOptionToString returns empty.  IF EXISTS is available on SQL Server 2016 or later, so returns error for it.
+func (m Mssql) DropTableOptionToString(o *sb.DropTableOption) (string, error) {
+	if o.IfExists {
+		return "", errors.New("dialects: mssql does not support IF EXISTS in DROP TABLE")
+	}
+	if o.Cascade {
+		return "", errors.New("dialects: mssql does not support CASCADE in DROP TABLE")
+	}
+	return "", nil
+}
+
+// TruncateSyntax returns TRUNCATE TABLE.  SQL Server always resets identity seed on TRUNCATE TABLE.
+func (m Mssql) TruncateSyntax(o *sb.TruncateOption) (sb.TruncateSyntax, error) {
+	if o.TableCount > 1 {
+		return sb.TruncateTable, errors.New("dialects: mssql can truncate only one table at once")
+	}
+	if o.RestartIdentity || o.Cascade {
+		return sb.TruncateTable, errors.New("dialects: mssql does not support RESTART IDENTITY and CASCADE in TRUNCATE TABLE")
+	}
+	return sb.TruncateTable, nil
+}
+
+// AlterTableCapabilities returns adding constraint.  SQL Server uses sp_rename procedure for renaming.
+func (m Mssql) AlterTableCapabilities() sb.AlterTableCapability {
+	return sb.AlterTableAddConstraint
+}
+
+// QueryCapabilities returns all but RECURSIVE.  SQL Server writes recursive CTE without RECURSIVE keyword.
+func (m Mssql) QueryCapabilities() sb.QueryCapability {
+	return sb.QueryTableAliasAs | sb.QueryExceptOperator | sb.QueryModOperator
+}
+
+// LengthFunction returns LEN.  Note that LEN does not count trailing spaces.
+func (m Mssql) LengthFunction() string {
+	return "LEN"
+}
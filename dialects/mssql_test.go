@@ -0,0 +1,90 @@
+package dialects
+
+import (
+	"testing"
+
+	sb "github.com/umisama/go-sqlbuilder"
+)
+
+func TestMssql(t *testing.T) {
+	table1 := sb.NewTable(
+		"TABLE_A",
+		&sb.TableOption{},
+		sb.IntColumn("id", &sb.ColumnOption{
+			PrimaryKey:    true,
+			AutoIncrement: true,
+		}),
+		sb.StringColumn("name", &sb.ColumnOption{
+			Size:    255,
+			NotNull: true,
+		}),
+		sb.StringColumn("memo", nil),
+		sb.DateColumn("created_at", nil),
+		sb.BytesColumn("data", nil),
+		sb.BoolColumn("enabled", &sb.ColumnOption{
+			Default: true,
+		}),
+	)
+
+	table2 := sb.NewTable(
+		"TABLE_B",
+		&sb.TableOption{},
+		sb.StringColumn("code", &sb.ColumnOption{
+			PrimaryKey: true,
+			Size:       10,
+		}),
+		sb.IntColumn("seq", &sb.ColumnOption{
+			AutoIncrement: true,
+		}),
+	)
+	table3 := sb.NewTable(
+		"TABLE_C",
+		&sb.TableOption{},
+		sb.IntColumn("seq", &sb.ColumnOption{
+			AutoIncrement: true,
+			Default:       1,
+		}),
+	)
+
+	runDialectTestCases(t, Mssql{}, []dialectTestCase{{
+		stmt: sb.Select(table1).Columns(table1.C("id")).
+			Where(table1.C("name").Eq("foo")).
+			OrderBy(false, table1.C("id")).
+			Limit(10).Offset(20),
+		query: `SELECT [TABLE_A].[id] FROM [TABLE_A] WHERE [TABLE_A].[name]=@p1 ORDER BY [TABLE_A].[id] ASC OFFSET @p2 ROWS FETCH NEXT @p3 ROWS ONLY;`,
+		args:  []interface{}{"foo", 20, 10},
+	}, {
+		stmt: sb.Select(table1).Columns(table1.C("id")).
+			OrderBy(true, table1.C("id")).
+			Limit(10),
+		query: `SELECT [TABLE_A].[id] FROM [TABLE_A] ORDER BY [TABLE_A].[id] DESC OFFSET 0 ROWS FETCH NEXT @p1 ROWS ONLY;`,
+		args:  []interface{}{10},
+	}, {
+		stmt: sb.Select(table1).Columns(table1.C("id")).
+			Limit(10),
+		errmsg: "dialects: mssql needs ORDER BY clause for OFFSET and FETCH",
+	}, {
+		stmt: sb.Select(table1).Columns(table1.C("id")).
+			Where(table1.C("enabled").Eq(true)),
+		query: `SELECT [TABLE_A].[id] FROM [TABLE_A] WHERE [TABLE_A].[enabled]=@p1;`,
+		args:  []interface{}{true},
+	}, {
+		stmt:  sb.CreateTable(table1),
+		query: `CREATE TABLE [TABLE_A] ( [id] BIGINT IDENTITY(1,1) PRIMARY KEY, [name] NVARCHAR(255) NOT NULL DEFAULT NULL, [memo] NVARCHAR(MAX) DEFAULT NULL, [created_at] DATETIME2 DEFAULT NULL, [data] VARBINARY(MAX) DEFAULT NULL, [enabled] BIT DEFAULT 1 );`,
+		args:  []interface{}{},
+	}, {
+		stmt:  sb.CreateTable(table2),
+		query: `CREATE TABLE [TABLE_B] ( [code] NVARCHAR(10) PRIMARY KEY, [seq] BIGINT IDENTITY(1,1) );`,
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.CreateTable(table3),
+		errmsg: "dialects: mssql does not support DEFAULT for IDENTITY column",
+	}, {
+		stmt:   sb.Select(table1).Columns(table1.C("id")).ForUpdate(),
+		errmsg: "dialects: mssql does not support row locking clause(FOR UPDATE, FOR SHARE, NOWAIT, SKIP LOCKED)",
+	}, {
+		stmt:  sb.Select(table1).Columns(sb.Length(table1.C("name"))),
+		query: `SELECT LEN([TABLE_A].[name]) FROM [TABLE_A];`,
+		args:  []interface{}{},
+	}})
+}
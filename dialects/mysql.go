@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	sb "github.com/umisama/go-sqlbuilder"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -17,6 +19,10 @@ func (m MySql) BindVar(i int) string {
 	return "?"
 }
 
+func (m MySql) MaxPlaceholders() int {
+	return 65535
+}
+
 func (m MySql) QuoteField(field interface{}) string {
 	str := ""
 	bracket := true
@@ -43,7 +49,7 @@ func (m MySql) QuoteField(field interface{}) string {
 		bracket = false
 	}
 	if bracket {
-		str = "`" + str + "`"
+		str = "`" + strings.Replace(str, "`", "``", -1) + "`"
 	}
 	return str
 }
@@ -95,39 +101,178 @@ func (m MySql) ColumnOptionToString(co *sb.ColumnOption) (string, error) {
 }
 
 func (m MySql) TableOptionToString(to *sb.TableOption) (string, error) {
-	opt := ""
-	if to.Unique != nil {
-		opt = str_append(opt, m.tableOptionUnique(to.Unique))
+	return table_constraints(to, m.QuoteField), nil
+}
+
+func (m MySql) LockOptionToString(lo *sb.LockOption) (string, error) {
+	return lock_option(lo, m.QuoteField)
+}
+
+// UpsertSyntax returns INSERT IGNORE for DoNothing.  Note that INSERT IGNORE turns other errors(ex: NOT NULL violation) into warnings too.
+func (m MySql) UpsertSyntax(uo *sb.UpsertOption) (sb.UpsertSyntax, error) {
+	if uo.Replace {
+		return sb.UpsertReplace, nil
+	}
+	if len(uo.Target) != 0 {
+		return sb.UpsertOnDuplicateKeyUpdate, errors.New("dialects: mysql does not support conflict target")
+	}
+	if uo.Where {
+		return sb.UpsertOnDuplicateKeyUpdate, errors.New("dialects: mysql does not support WHERE clause in ON DUPLICATE KEY UPDATE")
+	}
+	if uo.DoNothing {
+		return sb.UpsertInsertIgnore, nil
 	}
+	return sb.UpsertOnDuplicateKeyUpdate, nil
+}
 
-	return "", nil
+func (m MySql) SupportsReturning() bool {
+	return false
 }
 
-func (m MySql) tableOptionUnique(op [][]string) string {
-	opt := ""
-	first_op := true
-	for _, unique := range op {
-		if first_op {
-			first_op = false
-		} else {
-			opt += " "
-		}
+// ConcatOperator returns empty.  MySQL uses CONCAT() function because "||" is OR operator by default.
+func (m MySql) ConcatOperator() string {
+	return ""
+}
+
+// CastTypeToString returns type name for CAST().  MySQL accepts only limited types in CAST().
+func (m MySql) CastTypeToString(typ sb.ColumnType) (string, error) {
+	switch typ {
+	case sb.ColumnTypeInt, sb.ColumnTypeBool:
+		return "SIGNED", nil
+	case sb.ColumnTypeString:
+		return "CHAR", nil
+	case sb.ColumnTypeDate:
+		return "DATETIME", nil
+	case sb.ColumnTypeFloat:
+		return "DOUBLE", nil
+	case sb.ColumnTypeBytes:
+		return "BINARY", nil
+	}
+	return "", errors.New("dialects: unknown column type")
+}
+
+func (m MySql) PagingSyntax(po *sb.PagingOption) (sb.PagingSyntax, error) {
+	return sb.PagingLimitOffset, nil
+}
+
+// UpdateCapabilities returns ORDER BY and LIMIT.  MySQL does not accept OFFSET in UPDATE statement.
+func (m MySql) UpdateCapabilities() sb.UpdateCapability {
+	return sb.UpdateOrderBy | sb.UpdateLimit
+}
+
+// MaxIdentifierLength returns 64, the maximum length of table and column names.
+func (m MySql) MaxIdentifierLength() int {
+	return 64
+}
+
+func (m MySql) ForeignKeyActionToString(fk *sb.ForeignKey) (string, error) {
+	return foreign_key_actions(fk), nil
+}
+
+func (m MySql) LiteralToString(val interface{}) (string, error) {
+	return literal_to_string(val, "TRUE", "FALSE", func(str string) string {
+		// backslash is an escape character in MySQL by default.
+		return escape_quote(strings.Replace(str, "\\", "\\\\", -1))
+	})
+}
 
-		opt += "UNIQUE("
-		first := true
-		for _, col := range unique {
-			if first {
-				first = false
-			} else {
-				opt += ", "
+// table_constraints returns PRIMARY KEY and UNIQUE constraints of the to.
+func table_constraints(to *sb.TableOption, quote func(interface{}) string) string {
+	columns := func(names []string) string {
+		str := "("
+		for i, name := range names {
+			if i != 0 {
+				str += ", "
 			}
-			opt += m.QuoteField(col)
+			str += quote(name)
+		}
+		return str + ")"
+	}
+	constraints := make([]string, 0)
+	if len(to.PrimaryKey) != 0 {
+		constraints = append(constraints, "PRIMARY KEY"+columns(to.PrimaryKey))
+	}
+	for _, unique := range to.Unique {
+		constraints = append(constraints, "UNIQUE"+columns(unique))
+	}
+	for _, unique := range to.UniqueKeys {
+		str := ""
+		if len(unique.Name) != 0 {
+			str = "CONSTRAINT " + quote(unique.Name) + " "
+		}
+		constraints = append(constraints, str+"UNIQUE"+columns(unique.Columns))
+	}
+	return strings.Join(constraints, ", ")
+}
+
+// literal_to_string returns the val as SQL literal.  The bool is written as t or f.
+func literal_to_string(val interface{}, t, f string, escape func(string) string) (string, error) {
+	switch v := val.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case bool:
+		if v {
+			return t, nil
 		}
-		opt += ")"
+		return f, nil
+	case string:
+		return "'" + escape(v) + "'", nil
+	case time.Time:
+		return "'" + v.Format("2006-01-02 15:04:05") + "'", nil
+	case nil:
+		return "NULL", nil
+	}
+	return "", fmt.Errorf("dialects: %T can not be written as literal", val)
+}
+
+// escape_quote escapes single quotes in the str for string literal.
+func escape_quote(str string) string {
+	return strings.Replace(str, "'", "''", -1)
+}
+
+func foreign_key_actions(fk *sb.ForeignKey) string {
+	opt := ""
+	if fk.OnDelete != sb.ForeignKeyNoAction {
+		opt = str_append(opt, "ON DELETE "+fk.OnDelete.String())
+	}
+	if fk.OnUpdate != sb.ForeignKeyNoAction {
+		opt = str_append(opt, "ON UPDATE "+fk.OnUpdate.String())
 	}
 	return opt
 }
 
+// lock_option returns row locking clause.  The quote is used for table names in OF clause.
+func lock_option(lo *sb.LockOption, quote func(interface{}) string) (string, error) {
+	if lo.NoWait && lo.SkipLocked {
+		return "", errors.New("dialects: NOWAIT and SKIP LOCKED can not be used together")
+	}
+
+	opt := lo.Mode.String()
+	if len(opt) == 0 {
+		return "", errors.New("dialects: unknown lock mode")
+	}
+	if len(lo.Of) != 0 {
+		of := "OF "
+		for i, name := range lo.Of {
+			if i != 0 {
+				of += ", "
+			}
+			of += quote(name)
+		}
+		opt = str_append(opt, of)
+	}
+	if lo.NoWait {
+		opt = str_append(opt, "NOWAIT")
+	}
+	if lo.SkipLocked {
+		opt = str_append(opt, "SKIP LOCKED")
+	}
+
+	return opt, nil
+}
+
 func str_append(str, opt string) string {
 	if len(str) != 0 {
 		str += " "
@@ -135,3 +280,44 @@ func str_append(str, opt string) string {
 	str += opt
 	return str
 }
+
+// IndexCapabilities returns expression index(MySQL 8.0.13 or later) and DROP INDEX ... ON table.
+func (m MySql) IndexCapabilities() sb.IndexCapability {
+	return sb.IndexExpression | sb.IndexDropOnTable
+}
+
+// DropTableOptionToString returns empty.  MySQL accepts CASCADE but it does nothing, so returns error for it.
+func (m MySql) DropTableOptionToString(o *sb.DropTableOption) (string, error) {
+	if o.Cascade {
+		return "", errors.New("dialects: mysql does not support CASCADE in DROP TABLE")
+	}
+	return "", nil
+}
+
+// TruncateSyntax returns TRUNCATE TABLE.  MySQL always resets AUTO_INCREMENT counter on TRUNCATE TABLE.
+func (m MySql) TruncateSyntax(o *sb.TruncateOption) (sb.TruncateSyntax, error) {
+	if o.TableCount > 1 {
+		return sb.TruncateTable, errors.New("dialects: mysql can truncate only one table at once")
+	}
+	if o.RestartIdentity || o.Cascade {
+		return sb.TruncateTable, errors.New("dialects: mysql does not support RESTART IDENTITY and CASCADE in TRUNCATE TABLE")
+	}
+	return sb.TruncateTable, nil
+}
+
+// AlterTableCapabilities returns CHANGE COLUMN, FIRST/AFTER and others.  RENAME COLUMN is available on MySQL 8.0 or later.
+// RENAME TO is qualified with the database, MySQL moves the table to the current database without it.
+func (m MySql) AlterTableCapabilities() sb.AlterTableCapability {
+	return sb.AlterTableMultipleActions | sb.AlterTableCombineRename | sb.AlterTableColumnKeyword |
+		sb.AlterTableChangeColumn | sb.AlterTableColumnPosition | sb.AlterTableRenameColumn |
+		sb.AlterTableRenameTable | sb.AlterTableAddConstraint | sb.AlterTableRenameQualified
+}
+
+// QueryCapabilities returns WITH RECURSIVE.  Recursive CTE is available on MySQL 8.0 or later.
+func (m MySql) QueryCapabilities() sb.QueryCapability {
+	return sb.QueryRecursiveKeyword | sb.QueryTableAliasAs | sb.QueryExceptOperator | sb.QueryModOperator
+}
+
+func (m MySql) LengthFunction() string {
+	return "LENGTH"
+}
@@ -0,0 +1,248 @@
+package dialects
+
+import (
+	"errors"
+	"fmt"
+	sb "github.com/umisama/go-sqlbuilder"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Oracle is a dialect for Oracle Database 12c or later.
+type Oracle struct{}
+
+// QuerySuffix returns empty.  Oracle drivers reject a query terminated by ";".
+func (m Oracle) QuerySuffix() string {
+	return ""
+}
+
+func (m Oracle) BindVar(i int) string {
+	return ":" + strconv.Itoa(i)
+}
+
+func (m Oracle) quoteField(field interface{}) (string, bool) {
+	str := ""
+	bracket := true
+	switch t := field.(type) {
+	case string:
+		str = t
+	case []byte:
+		str = string(t)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		str = fmt.Sprint(field)
+	case float32, float64:
+		str = fmt.Sprint(field)
+	case time.Time:
+		str = t.Format("2006-01-02 15:04:05")
+	case bool:
+		// Oracle has no boolean type in SQL.
+		if t {
+			str = "1"
+		} else {
+			str = "0"
+		}
+		bracket = false
+	case nil:
+		str = "NULL"
+		bracket = false
+	}
+	return str, bracket
+}
+
+// MaxPlaceholders returns 65535, the maximum number of bind variables in a statement.
+func (m Oracle) MaxPlaceholders() int {
+	return 65535
+}
+
+func (m Oracle) QuoteField(field interface{}) string {
+	str, bracket := m.quoteField(field)
+	if bracket {
+		str = "\"" + strings.Replace(str, "\"", "\"\"", -1) + "\""
+	}
+	return str
+}
+
+func (m Oracle) ColumnTypeToString(cc sb.ColumnConfig) (string, error) {
+	if cc.Option().SqlType != "" {
+		return cc.Option().SqlType, nil
+	}
+
+	typ := ""
+	switch cc.Type() {
+	case sb.ColumnTypeInt:
+		typ = "NUMBER(19)"
+	case sb.ColumnTypeString:
+		if cc.Option().Size == 0 {
+			typ = "VARCHAR2(4000)"
+		} else {
+			typ = fmt.Sprintf("VARCHAR2(%d)", cc.Option().Size)
+		}
+	case sb.ColumnTypeDate:
+		typ = "TIMESTAMP"
+	case sb.ColumnTypeFloat:
+		typ = "BINARY_DOUBLE"
+	case sb.ColumnTypeBool:
+		typ = "NUMBER(1)"
+	case sb.ColumnTypeBytes:
+		typ = "BLOB"
+	}
+
+	if typ == "" {
+		return "", errors.New("dialects: unknown column type")
+	} else {
+		return typ, nil
+	}
+}
+
+// ColumnOptionToString returns column options.  Oracle needs DEFAULT and identity clause before constraints.
+func (m Oracle) ColumnOptionToString(co *sb.ColumnOption) (string, error) {
+	opt := ""
+	if co.AutoIncrement {
+		if co.Default != nil {
+			return "", errors.New("dialects: identity column can not have DEFAULT")
+		}
+		opt = str_append(opt, "GENERATED BY DEFAULT AS IDENTITY")
+	} else if co.Default == nil {
+		if !co.PrimaryKey {
+			opt = str_append(opt, "DEFAULT NULL")
+		}
+	} else {
+		str, bracket := m.quoteField(co.Default)
+		if bracket {
+			str = "'" + strings.Replace(str, "'", "''", -1) + "'"
+		}
+		opt = str_append(opt, "DEFAULT "+str)
+	}
+	if co.PrimaryKey {
+		opt = str_append(opt, "PRIMARY KEY")
+	}
+	if co.NotNull {
+		opt = str_append(opt, "NOT NULL")
+	}
+	if co.Unique {
+		opt = str_append(opt, "UNIQUE")
+	}
+
+	return opt, nil
+}
+
+func (m Oracle) TableOptionToString(to *sb.TableOption) (string, error) {
+	return table_constraints(to, m.QuoteField), nil
+}
+
+// LockOptionToString returns FOR UPDATE clause.  Oracle does not support FOR SHARE, and OF clause takes columns instead of tables.
+func (m Oracle) LockOptionToString(lo *sb.LockOption) (string, error) {
+	if lo.Mode == sb.LockForShare {
+		return "", errors.New("dialects: oracle does not support FOR SHARE")
+	}
+	if len(lo.Of) != 0 {
+		return "", errors.New("dialects: oracle does not support FOR UPDATE OF tables")
+	}
+	return lock_option(lo, m.QuoteField)
+}
+
+// UpsertSyntax returns error.  Oracle uses MERGE statement instead.
+func (m Oracle) UpsertSyntax(uo *sb.UpsertOption) (sb.UpsertSyntax, error) {
+	return sb.UpsertOnConflict, errors.New("dialects: oracle does not support upsert")
+}
+
+// SupportsReturning returns false.  RETURNING clause of Oracle needs INTO clause.
+func (m Oracle) SupportsReturning() bool {
+	return false
+}
+
+func (m Oracle) ConcatOperator() string {
+	return "||"
+}
+
+func (m Oracle) CastTypeToString(typ sb.ColumnType) (string, error) {
+	switch typ {
+	case sb.ColumnTypeInt:
+		return "NUMBER(19)", nil
+	case sb.ColumnTypeString:
+		return "VARCHAR2(4000)", nil
+	case sb.ColumnTypeDate:
+		return "TIMESTAMP", nil
+	case sb.ColumnTypeFloat:
+		return "BINARY_DOUBLE", nil
+	case sb.ColumnTypeBool:
+		return "NUMBER(1)", nil
+	case sb.ColumnTypeBytes:
+		return "BLOB", nil
+	}
+	return "", errors.New("dialects: unknown column type")
+}
+
+func (m Oracle) PagingSyntax(po *sb.PagingOption) (sb.PagingSyntax, error) {
+	return sb.PagingOffsetFetch, nil
+}
+
+func (m Oracle) UpdateCapabilities() sb.UpdateCapability {
+	return 0
+}
+
+// MaxIdentifierLength returns 30.  Oracle Database 12.2 or later accepts 128 bytes, but 12.1 accepts only 30 bytes.
+func (m Oracle) MaxIdentifierLength() int {
+	return 30
+}
+
+func (m Oracle) ForeignKeyActionToString(fk *sb.ForeignKey) (string, error) {
+	if fk.OnUpdate != sb.ForeignKeyNoAction {
+		return "", errors.New("dialects: oracle does not support ON UPDATE clause")
+	}
+	if fk.OnDelete == sb.ForeignKeyRestrict {
+		return "", errors.New("dialects: oracle does not support RESTRICT, use NO ACTION")
+	}
+	return foreign_key_actions(fk), nil
+}
+
+func (m Oracle) LiteralToString(val interface{}) (string, error) {
+	return literal_to_string(val, "1", "0", escape_quote)
+}
+
+// IndexCapabilities returns function-based index.
+func (m Oracle) IndexCapabilities() sb.IndexCapability {
+	return sb.IndexExpression
+}
+
+// DropTableOptionToString returns CASCADE CONSTRAINTS clause.  It drops foreign keys which refer the table.
+func (m Oracle) DropTableOptionToString(o *sb.DropTableOption) (string, error) {
+	if o.TableCount > 1 {
+		return "", errors.New("dialects: oracle can drop only one table at once")
+	}
+	if o.IfExists {
+		return "", errors.New("dialects: oracle does not support IF EXISTS in DROP TABLE")
+	}
+	if o.Cascade {
+		return "CASCADE CONSTRAINTS", nil
+	}
+	return "", nil
+}
+
+// TruncateSyntax returns TRUNCATE TABLE.  CASCADE is available on Oracle 12c or later.
+func (m Oracle) TruncateSyntax(o *sb.TruncateOption) (sb.TruncateSyntax, error) {
+	if o.TableCount > 1 {
+		return sb.TruncateTable, errors.New("dialects: oracle can truncate only one table at once")
+	}
+	if o.RestartIdentity {
+		return sb.TruncateTable, errors.New("dialects: oracle does not support RESTART IDENTITY in TRUNCATE TABLE")
+	}
+	return sb.TruncateTable, nil
+}
+
+// AlterTableCapabilities returns RENAME and adding constraint.
+// Oracle driver can not execute several statements in one query, so use one action per statement.
+func (m Oracle) AlterTableCapabilities() sb.AlterTableCapability {
+	return sb.AlterTableRenameColumn | sb.AlterTableRenameTable | sb.AlterTableAddConstraint
+}
+
+// QueryCapabilities returns none.  Oracle writes recursive CTE without RECURSIVE keyword,
+// table alias without AS, MINUS for EXCEPT and MOD() for "%".
+func (m Oracle) QueryCapabilities() sb.QueryCapability {
+	return 0
+}
+
+func (m Oracle) LengthFunction() string {
+	return "LENGTH"
+}
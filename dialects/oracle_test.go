@@ -0,0 +1,81 @@
+package dialects
+
+import (
+	"testing"
+
+	sb "github.com/umisama/go-sqlbuilder"
+)
+
+func TestOracle(t *testing.T) {
+	table1 := sb.NewTable(
+		"TABLE_A",
+		&sb.TableOption{},
+		sb.IntColumn("id", &sb.ColumnOption{
+			PrimaryKey:    true,
+			AutoIncrement: true,
+		}),
+		sb.StringColumn("name", &sb.ColumnOption{
+			Size:    255,
+			NotNull: true,
+			Default: "",
+		}),
+		sb.StringColumn("memo", nil),
+		sb.DateColumn("created_at", nil),
+		sb.FloatColumn("score", nil),
+		sb.BytesColumn("data", nil),
+		sb.BoolColumn("enabled", &sb.ColumnOption{
+			Default: true,
+		}),
+	)
+
+	sq := sb.Select(table1).Columns(table1.C("id")).ToSubquery("SQ")
+
+	runDialectTestCases(t, Oracle{}, []dialectTestCase{{
+		stmt: sb.Select(table1).Columns(table1.C("id")).
+			Where(table1.C("name").Eq("foo")).
+			OrderBy(false, table1.C("id")).
+			Limit(10).Offset(20),
+		query: `SELECT "TABLE_A"."id" FROM "TABLE_A" WHERE "TABLE_A"."name"=:1 ORDER BY "TABLE_A"."id" ASC OFFSET :2 ROWS FETCH NEXT :3 ROWS ONLY`,
+		args:  []interface{}{"foo", 20, 10},
+	}, {
+		stmt: sb.Select(table1).Columns(table1.C("id")).
+			Limit(10),
+		query: `SELECT "TABLE_A"."id" FROM "TABLE_A" OFFSET 0 ROWS FETCH NEXT :1 ROWS ONLY`,
+		args:  []interface{}{10},
+	}, {
+		stmt: sb.Insert(table1).
+			Columns(table1.C("name"), table1.C("enabled")).
+			Values("foo", true),
+		query: `INSERT INTO "TABLE_A" ( "name", "enabled" ) VALUES ( :1, :2 )`,
+		args:  []interface{}{"foo", true},
+	}, {
+		stmt:  sb.CreateTable(table1),
+		query: `CREATE TABLE "TABLE_A" ( "id" NUMBER(19) GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY, "name" VARCHAR2(255) DEFAULT '' NOT NULL, "memo" VARCHAR2(4000) DEFAULT NULL, "created_at" TIMESTAMP DEFAULT NULL, "score" BINARY_DOUBLE DEFAULT NULL, "data" BLOB DEFAULT NULL, "enabled" NUMBER(1) DEFAULT 1 )`,
+		args:  []interface{}{},
+	}, {
+		stmt:  sb.Select(table1).Columns(table1.C("id")).ForUpdate().SkipLocked(),
+		query: `SELECT "TABLE_A"."id" FROM "TABLE_A" FOR UPDATE SKIP LOCKED`,
+		args:  []interface{}{},
+	}, {
+		stmt:   sb.Select(table1).Columns(table1.C("id")).ForShare(),
+		errmsg: "dialects: oracle does not support FOR SHARE",
+	}, {
+		stmt:   sb.Update(table1).Set(table1.C("name"), "foo").Limit(1),
+		errmsg: "sqlbuilder: dialect does not support LIMIT clause in UPDATE statement.",
+	}, {
+		stmt:  sb.Select(sq).Columns(sq.C("id")),
+		query: `SELECT "SQ"."id" FROM ( SELECT "TABLE_A"."id" FROM "TABLE_A" ) "SQ"`,
+		args:  []interface{}{},
+	}, {
+		stmt:  sb.Except(sb.Select(table1).Columns(table1.C("id")), sb.Select(table1).Columns(table1.C("id")).Where(table1.C("enabled").Eq(true))),
+		query: `SELECT "TABLE_A"."id" FROM "TABLE_A" MINUS SELECT "TABLE_A"."id" FROM "TABLE_A" WHERE "TABLE_A"."enabled"=:1`,
+		args:  []interface{}{true},
+	}, {
+		stmt:  sb.Select(table1).Columns(sb.Mod(table1.C("id"), 2)),
+		query: `SELECT MOD("TABLE_A"."id", :1) FROM "TABLE_A"`,
+		args:  []interface{}{int64(2)},
+	}, {
+		stmt:   sb.Select(table1).Columns(table1.C("id").As("a_very_long_alias_over_thirty_bytes")),
+		errmsg: `sqlbuilder: identifier "a_very_long_alias_over_thirty_bytes" is longer than 30 bytes.`,
+	}})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	sb "github.com/umisama/go-sqlbuilder"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -46,10 +47,14 @@ func (m Postgresql) quoteField(field interface{}) (string, bool) {
 	return str, bracket
 }
 
+func (m Postgresql) MaxPlaceholders() int {
+	return 65535
+}
+
 func (m Postgresql) QuoteField(field interface{}) string {
 	str, bracket := m.quoteField(field)
 	if bracket {
-		str = "\"" + str + "\""
+		str = "\"" + strings.Replace(str, "\"", "\"\"", -1) + "\""
 	}
 	return str
 }
@@ -107,7 +112,7 @@ func (m Postgresql) ColumnOptionToString(co *sb.ColumnOption) (string, error) {
 	} else {
 		str, bracket := m.quoteField(co.Default)
 		if bracket {
-			str = "'" + str + "'"
+			str = "'" + strings.Replace(str, "'", "''", -1) + "'"
 		}
 		opt = str_append(opt, "DEFAULT "+str)
 	}
@@ -116,35 +121,96 @@ func (m Postgresql) ColumnOptionToString(co *sb.ColumnOption) (string, error) {
 }
 
 func (m Postgresql) TableOptionToString(to *sb.TableOption) (string, error) {
-	opt := ""
-	if to.Unique != nil {
-		opt = str_append(opt, m.tableOptionUnique(to.Unique))
+	return table_constraints(to, m.QuoteField), nil
+}
+
+func (m Postgresql) LockOptionToString(lo *sb.LockOption) (string, error) {
+	return lock_option(lo, m.QuoteField)
+}
+
+func (m Postgresql) UpsertSyntax(uo *sb.UpsertOption) (sb.UpsertSyntax, error) {
+	if uo.Replace {
+		return sb.UpsertOnConflict, errors.New("dialects: postgresql does not support INSERT OR REPLACE")
+	}
+	if uo.DoUpdate && len(uo.Target) == 0 {
+		return sb.UpsertOnConflict, errors.New("dialects: ON CONFLICT DO UPDATE needs conflict target")
 	}
+	return sb.UpsertOnConflict, nil
+}
 
-	return "", nil
+func (m Postgresql) SupportsReturning() bool {
+	return true
 }
 
-func (m Postgresql) tableOptionUnique(op [][]string) string {
-	opt := ""
-	first_op := true
-	for _, unique := range op {
-		if first_op {
-			first_op = false
-		} else {
-			opt += " "
-		}
+func (m Postgresql) ConcatOperator() string {
+	return "||"
+}
 
-		opt += "UNIQUE("
-		first := true
-		for _, col := range unique {
-			if first {
-				first = false
-			} else {
-				opt += ", "
-			}
-			opt += m.QuoteField(col)
-		}
-		opt += ")"
+func (m Postgresql) CastTypeToString(typ sb.ColumnType) (string, error) {
+	switch typ {
+	case sb.ColumnTypeInt:
+		return "BIGINT", nil
+	case sb.ColumnTypeString:
+		return "TEXT", nil
+	case sb.ColumnTypeDate:
+		return "TIMESTAMP", nil
+	case sb.ColumnTypeFloat:
+		return "REAL", nil
+	case sb.ColumnTypeBool:
+		return "BOOLEAN", nil
+	case sb.ColumnTypeBytes:
+		return "BYTEA", nil
 	}
-	return opt
+	return "", errors.New("dialects: unknown column type")
+}
+
+func (m Postgresql) PagingSyntax(po *sb.PagingOption) (sb.PagingSyntax, error) {
+	return sb.PagingLimitOffset, nil
+}
+
+func (m Postgresql) UpdateCapabilities() sb.UpdateCapability {
+	return 0
+}
+
+// MaxIdentifierLength returns 63, the default NAMEDATALEN-1.  Longer names are truncated silently by the server.
+func (m Postgresql) MaxIdentifierLength() int {
+	return 63
+}
+
+func (m Postgresql) ForeignKeyActionToString(fk *sb.ForeignKey) (string, error) {
+	return foreign_key_actions(fk), nil
+}
+
+func (m Postgresql) LiteralToString(val interface{}) (string, error) {
+	return literal_to_string(val, "TRUE", "FALSE", escape_quote)
+}
+
+func (m Postgresql) IndexCapabilities() sb.IndexCapability {
+	return sb.IndexPartial | sb.IndexUsing | sb.IndexExpression | sb.IndexDropIfExists
+}
+
+func (m Postgresql) DropTableOptionToString(o *sb.DropTableOption) (string, error) {
+	if o.Cascade {
+		return "CASCADE", nil
+	}
+	return "", nil
+}
+
+func (m Postgresql) TruncateSyntax(o *sb.TruncateOption) (sb.TruncateSyntax, error) {
+	return sb.TruncateTable, nil
+}
+
+// AlterTableCapabilities returns ALTER COLUMN and others.  RENAME can not be combined with other actions.
+// ALTER COLUMN is written only for changed properties, and auto increment(SERIAL) column can not be changed.
+func (m Postgresql) AlterTableCapabilities() sb.AlterTableCapability {
+	return sb.AlterTableMultipleActions | sb.AlterTableColumnKeyword | sb.AlterTableAlterColumn |
+		sb.AlterTableRenameColumn | sb.AlterTableRenameTable | sb.AlterTableAddConstraint
+}
+
+func (m Postgresql) QueryCapabilities() sb.QueryCapability {
+	return sb.QueryRecursiveKeyword | sb.QueryTableAliasAs | sb.QueryExceptOperator | sb.QueryModOperator
+}
+
+func (m Postgresql) LengthFunction() string {
+	return "LENGTH"
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	sb "github.com/umisama/go-sqlbuilder"
+	"strings"
 	"time"
 )
 
@@ -17,6 +18,11 @@ func (m Sqlite) BindVar(i int) string {
 	return "?"
 }
 
+// MaxPlaceholders returns 999, the default SQLITE_MAX_VARIABLE_NUMBER before 3.32.0.
+func (m Sqlite) MaxPlaceholders() int {
+	return 999
+}
+
 func (m Sqlite) QuoteField(field interface{}) string {
 	str := ""
 	bracket := true
@@ -43,7 +49,7 @@ func (m Sqlite) QuoteField(field interface{}) string {
 		bracket = false
 	}
 	if bracket {
-		str = "\"" + str + "\""
+		str = "\"" + strings.Replace(str, "\"", "\"\"", -1) + "\""
 	}
 	return str
 }
@@ -101,35 +107,104 @@ func (m Sqlite) ColumnOptionToString(co *sb.ColumnOption) (string, error) {
 }
 
 func (m Sqlite) TableOptionToString(to *sb.TableOption) (string, error) {
-	opt := ""
-	if to.Unique != nil {
-		opt = str_append(opt, m.tableOptionUnique(to.Unique))
+	return table_constraints(to, m.QuoteField), nil
+}
+
+func (m Sqlite) LockOptionToString(lo *sb.LockOption) (string, error) {
+	return "", errors.New("dialects: sqlite does not support row locking clause(FOR UPDATE, FOR SHARE, NOWAIT, SKIP LOCKED)")
+}
+
+func (m Sqlite) UpsertSyntax(uo *sb.UpsertOption) (sb.UpsertSyntax, error) {
+	if uo.Replace {
+		return sb.UpsertInsertOrReplace, nil
+	}
+	if uo.DoUpdate && len(uo.Target) == 0 {
+		return sb.UpsertOnConflict, errors.New("dialects: ON CONFLICT DO UPDATE needs conflict target")
 	}
+	return sb.UpsertOnConflict, nil
+}
 
-	return "", nil
+// SupportsReturning returns true.  RETURNING clause is available on SQLite 3.35.0 or later.
+func (m Sqlite) SupportsReturning() bool {
+	return true
 }
 
-func (m Sqlite) tableOptionUnique(op [][]string) string {
-	opt := ""
-	first_op := true
-	for _, unique := range op {
-		if first_op {
-			first_op = false
-		} else {
-			opt += " "
-		}
+func (m Sqlite) ConcatOperator() string {
+	return "||"
+}
 
-		opt += "UNIQUE("
-		first := true
-		for _, col := range unique {
-			if first {
-				first = false
-			} else {
-				opt += ", "
-			}
-			opt += m.QuoteField(col)
-		}
-		opt += ")"
+func (m Sqlite) CastTypeToString(typ sb.ColumnType) (string, error) {
+	switch typ {
+	case sb.ColumnTypeInt, sb.ColumnTypeBool:
+		return "INTEGER", nil
+	case sb.ColumnTypeString, sb.ColumnTypeDate:
+		return "TEXT", nil
+	case sb.ColumnTypeFloat:
+		return "REAL", nil
+	case sb.ColumnTypeBytes:
+		return "BLOB", nil
+	}
+	return "", errors.New("dialects: unknown column type")
+}
+
+func (m Sqlite) PagingSyntax(po *sb.PagingOption) (sb.PagingSyntax, error) {
+	return sb.PagingLimitOffset, nil
+}
+
+// UpdateCapabilities returns none.  ORDER BY and LIMIT in UPDATE statement need SQLITE_ENABLE_UPDATE_DELETE_LIMIT.
+func (m Sqlite) UpdateCapabilities() sb.UpdateCapability {
+	return 0
+}
+
+// MaxIdentifierLength returns 0.  SQLite has no limit of identifier length.
+func (m Sqlite) MaxIdentifierLength() int {
+	return 0
+}
+
+// ForeignKeyActionToString returns ON DELETE/ON UPDATE clause.  Note that SQLite checks foreign keys only if "PRAGMA foreign_keys" is enabled.
+func (m Sqlite) ForeignKeyActionToString(fk *sb.ForeignKey) (string, error) {
+	return foreign_key_actions(fk), nil
+}
+
+func (m Sqlite) LiteralToString(val interface{}) (string, error) {
+	return literal_to_string(val, "1", "0", escape_quote)
+}
+
+func (m Sqlite) IndexCapabilities() sb.IndexCapability {
+	return sb.IndexPartial | sb.IndexExpression | sb.IndexDropIfExists
+}
+
+func (m Sqlite) DropTableOptionToString(o *sb.DropTableOption) (string, error) {
+	if o.TableCount > 1 {
+		return "", errors.New("dialects: sqlite can drop only one table at once")
+	}
+	if o.Cascade {
+		return "", errors.New("dialects: sqlite does not support CASCADE in DROP TABLE")
 	}
-	return opt
+	return "", nil
+}
+
+// TruncateSyntax returns DELETE FROM.  SQLite has no TRUNCATE statement, but optimizes DELETE without WHERE clause.
+func (m Sqlite) TruncateSyntax(o *sb.TruncateOption) (sb.TruncateSyntax, error) {
+	if o.TableCount > 1 {
+		return sb.TruncateDeleteFrom, errors.New("dialects: sqlite can truncate only one table at once")
+	}
+	if o.RestartIdentity || o.Cascade {
+		return sb.TruncateDeleteFrom, errors.New("dialects: sqlite does not support RESTART IDENTITY and CASCADE in TRUNCATE TABLE")
+	}
+	return sb.TruncateDeleteFrom, nil
+}
+
+// AlterTableCapabilities returns ADD COLUMN and RENAME.  SQLite accepts one action per statement.
+// RENAME COLUMN is available on SQLite 3.25.0 or later, and DROP COLUMN is available on SQLite 3.35.0 or later.
+func (m Sqlite) AlterTableCapabilities() sb.AlterTableCapability {
+	return sb.AlterTableColumnKeyword | sb.AlterTableRenameColumn | sb.AlterTableRenameTable
+}
+
+func (m Sqlite) QueryCapabilities() sb.QueryCapability {
+	return sb.QueryRecursiveKeyword | sb.QueryTableAliasAs | sb.QueryExceptOperator | sb.QueryModOperator
+}
+
+func (m Sqlite) LengthFunction() string {
+	return "LENGTH"
 }
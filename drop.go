@@ -1,32 +1,75 @@
 package sqlbuilder
 
-// DeleteTableStatement represents a "DROP TABLE" statement.
+// DropTableOption represents options of DROP TABLE statement.
+// Dialects handle this for render the trailing option, or return an error for unsupported option.
+type DropTableOption struct {
+	TableCount int
+	IfExists   bool
+	Cascade    bool
+}
+
+// DropTableStatement represents a "DROP TABLE" statement.
 type DropTableStatement struct {
-	table Table
+	tables   []Table
+	ifExists bool
+	cascade  bool
+
+	dialect Dialect
 
 	err error
 }
 
-// DropTable returns new "DROP TABLE" statement. The table is Table object to drop.
-func DropTable(tbl Table) *DropTableStatement {
-	if tbl == nil {
+// DropTable returns new "DROP TABLE" statement. The tbls are Table objects to drop.
+func DropTable(tbls ...Table) *DropTableStatement {
+	if len(tbls) == 0 {
 		return &DropTableStatement{
 			err: newError("table is nil."),
 		}
 	}
-	if _, ok := tbl.(*table); !ok {
-		return &DropTableStatement{
-			err: newError("table is not natural table."),
+	for _, tbl := range tbls {
+		if tbl == nil {
+			return &DropTableStatement{
+				err: newError("table is nil."),
+			}
+		}
+		if _, ok := tbl.(*table); !ok {
+			return &DropTableStatement{
+				err: newError("table is not natural table."),
+			}
 		}
 	}
 	return &DropTableStatement{
-		table: tbl,
+		tables: tbls,
 	}
 }
 
+// IfExists sets "IF EXISTS" clause.
+func (b *DropTableStatement) IfExists() *DropTableStatement {
+	if b.err != nil {
+		return b
+	}
+	b.ifExists = true
+	return b
+}
+
+// Cascade sets "CASCADE" option, drops objects which depend on the tables.
+func (b *DropTableStatement) Cascade() *DropTableStatement {
+	if b.err != nil {
+		return b
+	}
+	b.cascade = true
+	return b
+}
+
 // ToSql generates query string, placeholder arguments, and returns err on errors.
 func (b *DropTableStatement) ToSql() (query string, args []interface{}, err error) {
-	bldr := newBuilder()
+	return b.ToSqlWith(b.dialect)
+}
+
+// ToSqlWith generates query string, placeholder arguments with the d, and returns err on errors.
+// The default dialect is used if the d is nil.
+func (b *DropTableStatement) ToSqlWith(d Dialect) (query string, args []interface{}, err error) {
+	bldr := newBuilderWith(d)
 	defer func() {
 		query, args, err = bldr.Query(), bldr.Args(), bldr.Err()
 	}()
@@ -35,7 +78,110 @@ func (b *DropTableStatement) ToSql() (query string, args []interface{}, err erro
 		return
 	}
 
+	opt, e := bldr.dialect.DropTableOptionToString(&DropTableOption{
+		TableCount: len(b.tables),
+		IfExists:   b.ifExists,
+		Cascade:    b.cascade,
+	})
+	if e != nil {
+		bldr.SetError(e)
+		return
+	}
+
 	bldr.Append("DROP TABLE ")
-	bldr.AppendItem(b.table)
+	if b.ifExists {
+		bldr.Append("IF EXISTS ")
+	}
+	for i, tbl := range b.tables {
+		if i != 0 {
+			bldr.Append(", ")
+		}
+		bldr.AppendItem(tbl)
+	}
+	if len(opt) != 0 {
+		bldr.Append(" " + opt)
+	}
+	return
+}
+
+// DropIndexStatement represents a "DROP INDEX" statement.
+type DropIndexStatement struct {
+	table    Table
+	name     string
+	ifExists bool
+
+	dialect Dialect
+
+	err error
+}
+
+// DropIndex returns new "DROP INDEX" statement. The name is index's name, and the tbl is Table object which has the index.
+func DropIndex(tbl Table, name string) *DropIndexStatement {
+	if tbl == nil {
+		return &DropIndexStatement{
+			err: newError("table is nil."),
+		}
+	}
+	if _, ok := tbl.(*table); !ok {
+		return &DropIndexStatement{
+			err: newError("table is not natural table."),
+		}
+	}
+	if len(name) == 0 {
+		return &DropIndexStatement{
+			err: newError("name was not setted."),
+		}
+	}
+	return &DropIndexStatement{
+		table: tbl,
+		name:  name,
+	}
+}
+
+// IfExists sets "IF EXISTS" clause.
+func (b *DropIndexStatement) IfExists() *DropIndexStatement {
+	if b.err != nil {
+		return b
+	}
+	b.ifExists = true
+	return b
+}
+
+// ToSql generates query string, placeholder arguments, and returns err on errors.
+func (b *DropIndexStatement) ToSql() (query string, args []interface{}, err error) {
+	return b.ToSqlWith(b.dialect)
+}
+
+// ToSqlWith generates query string, placeholder arguments with the d, and returns err on errors.
+// The default dialect is used if the d is nil.
+func (b *DropIndexStatement) ToSqlWith(d Dialect) (query string, args []interface{}, err error) {
+	bldr := newBuilderWith(d)
+	defer func() {
+		query, args, err = bldr.Query(), bldr.Args(), bldr.Err()
+	}()
+	if b.err != nil {
+		bldr.SetError(b.err)
+		return
+	}
+
+	bldr.Append("DROP INDEX ")
+	if b.ifExists {
+		if bldr.dialect.IndexCapabilities()&IndexDropIfExists == 0 {
+			bldr.SetError(newError("dialect does not support IF EXISTS clause in DROP INDEX statement."))
+			return
+		}
+		bldr.Append("IF EXISTS ")
+	}
+	if bldr.dialect.IndexCapabilities()&IndexDropOnTable != 0 {
+		bldr.Append(bldr.QuoteIdent(b.name) + " ON ")
+		bldr.AppendItem(b.table)
+		return
+	}
+
+	// index is in the same schema as the table.
+	if opt := b.table.Option(); opt != nil && len(opt.Schema) != 0 {
+		bldr.Append(bldr.QuoteIdent(opt.Schema) + ".")
+	}
+	bldr.Append(bldr.QuoteIdent(b.name))
 	return
 }
@@ -28,11 +28,26 @@ func TestDropTable(t *testing.T) {
 		query:  `DROP TABLE "TABLE_A";`,
 		args:   []interface{}{},
 		errmsg: "",
+	}, {
+		stmt:   DropTable(table1, table2).IfExists().Cascade(),
+		query:  `DROP TABLE IF EXISTS "TABLE_A", "TABLE_B" CASCADE;`,
+		args:   []interface{}{},
+		errmsg: "",
 	}, {
 		stmt:   DropTable(nil),
 		query:  ``,
 		args:   []interface{}{},
 		errmsg: "sqlbuilder: table is nil.",
+	}, {
+		stmt:   DropTable(),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: table is nil.",
+	}, {
+		stmt:   DropTable(table1, tableJoined),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: table is not natural table.",
 	}, {
 		stmt:   DropTable(tableJoined),
 		query:  ``,
@@ -46,3 +61,50 @@ func TestDropTable(t *testing.T) {
 		}
 	}
 }
+
+func TestDropIndex(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+	)
+	table2 := NewTable(
+		"TABLE_B",
+		&TableOption{
+			Schema: "analytics",
+		},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+	)
+
+	var cases = []statementTestCase{{
+		stmt:   DropIndex(table1, "I_TABLE_A"),
+		query:  `DROP INDEX "I_TABLE_A";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   DropIndex(table2, "I_TABLE_B").IfExists(),
+		query:  `DROP INDEX IF EXISTS "analytics"."I_TABLE_B";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   DropIndex(table1, ""),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: name was not setted.",
+	}, {
+		stmt:   DropIndex(nil, "I_TABLE_A"),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: table is nil.",
+	}}
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
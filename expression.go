@@ -0,0 +1,252 @@
+package sqlbuilder
+
+type expressionType int
+
+const (
+	arithmetic_expression expressionType = iota
+	negative_expression
+	concat_expression
+)
+
+// Expression represents an arithmetic or string expression(ex: "col" + 1).  This can be use in the same way as Column.
+type Expression interface {
+	Column
+
+	columns() []Column
+}
+
+type expressionImpl struct {
+	typ      expressionType
+	operator string
+	operands []serializable
+	ctyp     ColumnType
+	err      error
+}
+
+// Add returns an expression for "left + right".  Type for left/right is Column, SqlFunc or literal value.
+func Add(left, right interface{}) Expression {
+	return newArithmeticExpression("+", left, right)
+}
+
+// Sub returns an expression for "left - right".  Type for left/right is Column, SqlFunc or literal value.
+func Sub(left, right interface{}) Expression {
+	return newArithmeticExpression("-", left, right)
+}
+
+// Mul returns an expression for "left * right".  Type for left/right is Column, SqlFunc or literal value.
+func Mul(left, right interface{}) Expression {
+	return newArithmeticExpression("*", left, right)
+}
+
+// Div returns an expression for "left / right".  Type for left/right is Column, SqlFunc or literal value.
+func Div(left, right interface{}) Expression {
+	return newArithmeticExpression("/", left, right)
+}
+
+// Mod returns an expression for "left % right".  Type for left/right is Column, SqlFunc or literal value.
+func Mod(left, right interface{}) Expression {
+	return newArithmeticExpression("%", left, right)
+}
+
+// Neg returns an expression for "-operand".  Type for operand is Column, SqlFunc or literal value.
+func Neg(operand interface{}) Expression {
+	op := toOperand(operand)
+	return &expressionImpl{
+		typ:      negative_expression,
+		operands: []serializable{op},
+		ctyp:     operandType(op),
+	}
+}
+
+// Concat returns an expression for string concatenation of the operands.
+// Type for operands is Column, SqlFunc or literal value.
+func Concat(operands ...interface{}) Expression {
+	m := &expressionImpl{
+		typ:      concat_expression,
+		operands: make([]serializable, 0, len(operands)),
+		ctyp:     ColumnTypeString,
+	}
+	if len(operands) < 2 {
+		m.err = newError("Concat needs two or more operands.")
+	}
+	for _, op := range operands {
+		m.operands = append(m.operands, toOperand(op))
+	}
+	return m
+}
+
+func newArithmeticExpression(operator string, left, right interface{}) *expressionImpl {
+	l, r := toOperand(left), toOperand(right)
+	lt, rt := operandType(l), operandType(r)
+
+	ctyp := ColumnTypeAny
+	switch {
+	case lt == ColumnTypeFloat && (rt == ColumnTypeFloat || rt == ColumnTypeInt):
+		ctyp = ColumnTypeFloat
+	case lt == ColumnTypeInt && rt == ColumnTypeFloat:
+		ctyp = ColumnTypeFloat
+	case lt == ColumnTypeInt && rt == ColumnTypeInt:
+		ctyp = ColumnTypeInt
+	}
+	return &expressionImpl{
+		typ:      arithmetic_expression,
+		operator: operator,
+		operands: []serializable{l, r},
+		ctyp:     ctyp,
+	}
+}
+
+func toOperand(v interface{}) serializable {
+	if col, ok := v.(Column); ok {
+		return col
+	}
+	return toLiteral(v)
+}
+
+func operandType(op serializable) ColumnType {
+	switch t := op.(type) {
+	case Column:
+		if cc := t.config(); cc != nil {
+			return cc.Type()
+		}
+	case literal:
+		return literalType(t)
+	}
+	return ColumnTypeAny
+}
+
+func (m *expressionImpl) As(alias string) Column {
+	return &aliasColumn{
+		column: m,
+		alias:  alias,
+	}
+}
+
+func (m *expressionImpl) column_name() string {
+	return ""
+}
+
+func (m *expressionImpl) config() ColumnConfig {
+	return newColumnConfigImpl("", m.ctyp, nil)
+}
+
+func (m *expressionImpl) acceptType(val interface{}) bool {
+	return acceptLiteralType(m.ctyp, false, val)
+}
+
+func (m *expressionImpl) serialize(bldr *builder) {
+	if m.err != nil {
+		bldr.SetError(m.err)
+		return
+	}
+
+	switch m.typ {
+	case arithmetic_expression:
+		if m.operator == "%" && bldr.dialect.QueryCapabilities()&QueryModOperator == 0 {
+			bldr.Append("MOD(")
+			bldr.AppendItems(m.operands, ", ")
+			bldr.Append(")")
+			return
+		}
+		for i, op := range m.operands {
+			if i != 0 {
+				bldr.Append(" " + m.operator + " ")
+			}
+			serializeOperand(bldr, op)
+		}
+	case negative_expression:
+		bldr.Append("-")
+		serializeOperand(bldr, m.operands[0])
+	case concat_expression:
+		operator := bldr.dialect.ConcatOperator()
+		if len(operator) == 0 {
+			bldr.Append("CONCAT(")
+			bldr.AppendItems(m.operands, ", ")
+			bldr.Append(")")
+			return
+		}
+		for i, op := range m.operands {
+			if i != 0 {
+				bldr.Append(" " + operator + " ")
+			}
+			serializeOperand(bldr, op)
+		}
+	}
+}
+
+// serializeOperand serializes the op with parentheses if it is an expression.
+func serializeOperand(bldr *builder, op serializable) {
+	if _, ok := op.(*expressionImpl); ok {
+		bldr.Append("( ")
+		bldr.AppendItem(op)
+		bldr.Append(" )")
+	} else {
+		bldr.AppendItem(op)
+	}
+}
+
+func (m *expressionImpl) columns() []Column {
+	list := make([]Column, 0)
+	for _, op := range m.operands {
+		if col, ok := op.(Column); ok {
+			list = append(list, col)
+		}
+	}
+	return list
+}
+
+func (left *expressionImpl) Eq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "=")
+}
+
+func (left *expressionImpl) NotEq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "<>")
+}
+
+func (left *expressionImpl) Gt(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, ">")
+}
+
+func (left *expressionImpl) GtEq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, ">=")
+}
+
+func (left *expressionImpl) Lt(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "<")
+}
+
+func (left *expressionImpl) LtEq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "<=")
+}
+
+func (left *expressionImpl) Like(right string) Condition {
+	return newBinaryOperationCondition(left, right, " LIKE ")
+}
+
+func (left *expressionImpl) NotLike(right string) Condition {
+	return newBinaryOperationCondition(left, right, " NOT LIKE ")
+}
+
+func (left *expressionImpl) Between(lower, higher interface{}) Condition {
+	return newBetweenCondition(left, lower, higher)
+}
+
+func (left *expressionImpl) NotBetween(lower, higher interface{}) Condition {
+	return newNotBetweenCondition(left, lower, higher)
+}
+
+func (left *expressionImpl) In(vals ...interface{}) Condition {
+	return newInCondition(left, vals...)
+}
+
+func (left *expressionImpl) NotIn(vals ...interface{}) Condition {
+	return newNotInCondition(left, vals...)
+}
+
+func (left *expressionImpl) IsNull() Condition {
+	return newNullCondition(left, false)
+}
+
+func (left *expressionImpl) IsNotNull() Condition {
+	return newNullCondition(left, true)
+}
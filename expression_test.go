@@ -0,0 +1,113 @@
+package sqlbuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpressionImplements(t *testing.T) {
+	fnImplColumn := func(i interface{}) bool {
+		return reflect.TypeOf(i).Implements(reflect.TypeOf(new(Column)).Elem())
+	}
+	if !fnImplColumn(&expressionImpl{}) {
+		t.Errorf("fail")
+	}
+}
+
+func TestExpression(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("hits", nil),
+		FloatColumn("price", nil),
+		StringColumn("name", nil),
+		StringColumn("label", nil),
+	)
+	table2 := NewTable(
+		"TABLE_B",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+	)
+
+	var cases = []statementTestCase{{
+		stmt: Update(table1).
+			Set(table1.C("hits"), Add(table1.C("hits"), 1)).
+			Where(table1.C("id").Eq(1)),
+		query:  `UPDATE "TABLE_A" SET "hits"="TABLE_A"."hits" + ? WHERE "TABLE_A"."id"=?;`,
+		args:   []interface{}{int64(1), int64(1)},
+		errmsg: "",
+	}, {
+		stmt: Select(table1).
+			Columns(Mul(table1.C("price"), table1.C("hits")).As("total")),
+		query:  `SELECT "TABLE_A"."price" * "TABLE_A"."hits" AS "total" FROM "TABLE_A";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt: Select(table1).
+			Columns(Neg(Sub(table1.C("hits"), Mod(table1.C("id"), 2)))).
+			Where(Div(table1.C("hits"), 2).Gt(10)),
+		query:  `SELECT -( "TABLE_A"."hits" - ( "TABLE_A"."id" % ? ) ) FROM "TABLE_A" WHERE "TABLE_A"."hits" / ?>?;`,
+		args:   []interface{}{int64(2), int64(2), int64(10)},
+		errmsg: "",
+	}, {
+		stmt: Update(table1).
+			Set(table1.C("label"), Concat(table1.C("name"), "-", table1.C("id"))),
+		query:  `UPDATE "TABLE_A" SET "label"="TABLE_A"."name" || ? || "TABLE_A"."id";`,
+		args:   []interface{}{"-"},
+		errmsg: "",
+	}, {
+		stmt: Select(table1).
+			Columns(Add(Func("max", table1.C("hits")), 1)),
+		query:  `SELECT max("TABLE_A"."hits") + ? FROM "TABLE_A";`,
+		args:   []interface{}{int64(1)},
+		errmsg: "",
+	}, {
+		stmt: Update(table1).
+			Set(table1.C("hits"), Mul(table1.C("price"), 2)),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: int column not accept float column.",
+	}, {
+		stmt: Update(table1).
+			Set(table1.C("hits"), Concat(table1.C("name"), "a")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: int column not accept string column.",
+	}, {
+		stmt: Update(table1).
+			Set(table1.C("price"), table1.C("hits")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: float column not accept int column.",
+	}, {
+		stmt: Update(table1).
+			Set(table1.C("hits"), Add(table2.C("id"), 1)),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column not found in FROM.",
+	}, {
+		stmt: Select(table1).
+			Columns(Add(table2.C("id"), 1)),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column not found in FROM.",
+	}, {
+		stmt: Select(table1).
+			Columns(Concat(table1.C("name"))),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: Concat needs two or more operands.",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
@@ -2,13 +2,59 @@ package sqlbuilder
 
 // InsertStatement represents a INSERT statement.
 type InsertStatement struct {
-	columns ColumnList
-	values  []literal
-	into    Table
+	columns   ColumnList
+	rows      [][]literal
+	source    *SelectStatement
+	into      Table
+	upsert    *upsert
+	returning returningColumnList
+
+	dialect Dialect
 
 	err error
 }
 
+// UpsertSyntax represents a syntax of upsert which a dialect uses.
+type UpsertSyntax int
+
+const (
+	// INSERT ... ON CONFLICT ( target ) DO NOTHING / DO UPDATE SET ... WHERE ...
+	UpsertOnConflict UpsertSyntax = iota
+	// INSERT ... ON DUPLICATE KEY UPDATE ...
+	UpsertOnDuplicateKeyUpdate
+	// INSERT IGNORE ...
+	// Note that this ignores other errors(ex: NOT NULL or type conversion) too, not only duplicate keys.
+	UpsertInsertIgnore
+	// INSERT OR REPLACE ...
+	UpsertInsertOrReplace
+	// REPLACE ...
+	UpsertReplace
+)
+
+// UpsertOption represents a form of upsert.
+// Dialects handle this for choose its syntax.
+type UpsertOption struct {
+	Target    []string
+	DoNothing bool
+	DoUpdate  bool
+	Where     bool
+	Replace   bool
+}
+
+type upsert struct {
+	target    ColumnList
+	doNothing bool
+	set       []upsertValue
+	where     Condition
+	replace   bool
+}
+
+type upsertValue struct {
+	col      Column
+	val      literal
+	excluded bool
+}
+
 // Insert returns new INSERT statement. The table is Table object for into.
 func Insert(into Table) *InsertStatement {
 	if into == nil {
@@ -24,7 +70,7 @@ func Insert(into Table) *InsertStatement {
 	return &InsertStatement{
 		into:    into,
 		columns: make(ColumnList, 0),
-		values:  make([]literal, 0),
+		rows:    make([][]literal, 0),
 	}
 }
 
@@ -44,21 +90,52 @@ func (b *InsertStatement) Columns(columns ...Column) *InsertStatement {
 	return b
 }
 
-// Values sets VALUES clause. This overwrite old results of Values() or Set().
+// Values sets VALUES clause. This overwrite old results of Values(), Rows(), AddRow() or Set().
 func (b *InsertStatement) Values(values ...interface{}) *InsertStatement {
 	if b.err != nil {
 		return b
 	}
-	sl := make([]literal, len(values))
-	for i := range values {
-		sl[i] = toLiteral(values[i])
+	b.rows = [][]literal{toLiterals(values)}
+	return b
+}
+
+// Rows sets VALUES clause with multiple rows.  This overwrite old results of Values(), Rows(), AddRow() or Set().
+func (b *InsertStatement) Rows(rows ...[]interface{}) *InsertStatement {
+	if b.err != nil {
+		return b
+	}
+	b.rows = make([][]literal, len(rows))
+	for i := range rows {
+		b.rows[i] = toLiterals(rows[i])
 	}
-	b.values = sl
+	return b
+}
+
+// AddRow appends a row to VALUES clause.
+func (b *InsertStatement) AddRow(values ...interface{}) *InsertStatement {
+	if b.err != nil {
+		return b
+	}
+	b.rows = append(b.rows, toLiterals(values))
+	return b
+}
+
+// Select sets SELECT statement as source of rows(INSERT ... SELECT).
+// Number and types of columns in the stat must be same as the insert's one.
+func (b *InsertStatement) Select(stat *SelectStatement) *InsertStatement {
+	if b.err != nil {
+		return b
+	}
+	if stat == nil {
+		b.err = newError("SELECT statement is nil.")
+		return b
+	}
+	b.source = stat
 	return b
 }
 
 // Set sets the column and value togeter.
-// Set cannot be called with Columns() or Values() in a statement.
+// Set cannot be called with Columns(), Values(), Rows() or AddRow() in a statement.
 func (b *InsertStatement) Set(column Column, value interface{}) *InsertStatement {
 	if b.err != nil {
 		return b
@@ -67,14 +144,220 @@ func (b *InsertStatement) Set(column Column, value interface{}) *InsertStatement
 		b.err = newError("column not found in FROM.")
 		return b
 	}
+	if len(b.rows) > 1 {
+		b.err = newError("Set can not be used with multiple rows.")
+		return b
+	}
+	if len(b.rows) == 0 {
+		b.rows = append(b.rows, make([]literal, 0))
+	}
 	b.columns = append(b.columns, column)
-	b.values = append(b.values, toLiteral(value))
+	b.rows[0] = append(b.rows[0], toLiteral(value))
+	return b
+}
+
+// OnConflict sets conflict target columns for upsert.
+func (b *InsertStatement) OnConflict(columns ...Column) *InsertStatement {
+	if b.err != nil {
+		return b
+	}
+	for _, col := range columns {
+		if !b.into.hasColumn(col) {
+			b.err = newError("column not found in table.")
+			return b
+		}
+	}
+	b.upsertClause().target = ColumnList(columns)
+	return b
+}
+
+// DoNothing sets the statement to do nothing on conflict.
+func (b *InsertStatement) DoNothing() *InsertStatement {
+	if b.err != nil {
+		return b
+	}
+	b.upsertClause().doNothing = true
+	return b
+}
+
+// DoUpdate sets the statement to update the column with the val on conflict.  Call many time for update multi columns.
+func (b *InsertStatement) DoUpdate(col Column, val interface{}) *InsertStatement {
+	if b.err != nil {
+		return b
+	}
+	if !b.into.hasColumn(col) {
+		b.err = newError("column not found in table.")
+		return b
+	}
+	u := b.upsertClause()
+	u.set = append(u.set, upsertValue{
+		col: col,
+		val: toLiteral(val),
+	})
 	return b
 }
 
+// DoUpdateExcluded sets the statement to update the columns with the values proposed for insertion on conflict.
+// This refers the excluded row(ex: excluded."col" or VALUES(`col`)).
+func (b *InsertStatement) DoUpdateExcluded(columns ...Column) *InsertStatement {
+	if b.err != nil {
+		return b
+	}
+	u := b.upsertClause()
+	for _, col := range columns {
+		if !b.into.hasColumn(col) {
+			b.err = newError("column not found in table.")
+			return b
+		}
+		u.set = append(u.set, upsertValue{
+			col:      col,
+			excluded: true,
+		})
+	}
+	return b
+}
+
+// DoUpdateWhere sets WHERE clause for the update on conflict.
+func (b *InsertStatement) DoUpdateWhere(cond Condition) *InsertStatement {
+	if b.err != nil {
+		return b
+	}
+	if cond == nil {
+		b.err = newError("condition is nil.")
+		return b
+	}
+	for _, col := range cond.columns() {
+		if !b.into.hasColumn(col) {
+			b.err = newError("column not found in table.")
+			return b
+		}
+	}
+	b.upsertClause().where = cond
+	return b
+}
+
+// OrReplace sets the statement to replace the conflicting row(ex: INSERT OR REPLACE, REPLACE INTO).
+func (b *InsertStatement) OrReplace() *InsertStatement {
+	if b.err != nil {
+		return b
+	}
+	b.upsertClause().replace = true
+	return b
+}
+
+func (b *InsertStatement) upsertClause() *upsert {
+	if b.upsert == nil {
+		b.upsert = &upsert{}
+	}
+	return b.upsert
+}
+
+func (m *upsert) option() (*UpsertOption, error) {
+	opt := &UpsertOption{
+		Target:    make([]string, len(m.target)),
+		DoNothing: m.doNothing,
+		DoUpdate:  len(m.set) != 0,
+		Where:     m.where != nil,
+		Replace:   m.replace,
+	}
+	for i, col := range m.target {
+		opt.Target[i] = col.column_name()
+	}
+
+	switch {
+	case opt.DoNothing && opt.DoUpdate:
+		return nil, newError("DoNothing and DoUpdate can not be used together.")
+	case opt.Replace && (opt.DoNothing || opt.DoUpdate || len(opt.Target) != 0):
+		return nil, newError("OrReplace can not be used with OnConflict, DoNothing or DoUpdate.")
+	case !opt.Replace && !opt.DoNothing && !opt.DoUpdate:
+		return nil, newError("DoNothing or DoUpdate is needed for upsert.")
+	case opt.Where && !opt.DoUpdate:
+		return nil, newError("DoUpdateWhere needs DoUpdate.")
+	}
+	return opt, nil
+}
+
+// Returning sets RETURNING clause.  The columns are returned from inserted rows.
+func (b *InsertStatement) Returning(columns ...Column) *InsertStatement {
+	if b.err != nil {
+		return b
+	}
+	for _, col := range columns {
+		if !b.into.hasColumn(col) {
+			b.err = newError("column not found in table.")
+			return b
+		}
+	}
+	b.returning = returningColumnList(columns)
+	return b
+}
+
+// getDialect returns the statement's dialect or the default dialect.
+func (b *InsertStatement) getDialect() Dialect {
+	if b.dialect != nil {
+		return b.dialect
+	}
+	return dialect()
+}
+
+// Chunk splits the statement into statements each of which has placeholders less than or equal to the max.
+// Placeholders in the upsert clause are counted for each statement.
+// Use dialect's limit(Dialect.MaxPlaceholders) if the max is zero or less.
+func (b *InsertStatement) Chunk(max int) ([]*InsertStatement, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if max <= 0 {
+		max = b.getDialect().MaxPlaceholders()
+	}
+
+	columns := len(b.columns)
+	if columns == 0 {
+		columns = len(b.into.Columns())
+	}
+	if max <= 0 || columns == 0 || len(b.rows) == 0 {
+		return []*InsertStatement{b}, nil
+	}
+
+	// placeholders out of VALUES clause.  ex: DO UPDATE SET "a"=?
+	extra := 0
+	if b.upsert != nil {
+		extra = b.upsert.placeholders(b.getDialect())
+	}
+	per_stmt := (max - extra) / columns
+	if per_stmt <= 0 {
+		return nil, newError("a row needs %d placeholders, but limit is %d.", columns+extra, max)
+	}
+	stmts := make([]*InsertStatement, 0, (len(b.rows)+per_stmt-1)/per_stmt)
+	for i := 0; i < len(b.rows); i += per_stmt {
+		end := i + per_stmt
+		if end > len(b.rows) {
+			end = len(b.rows)
+		}
+		stmt := *b
+		stmt.rows = make([][]literal, end-i)
+		copy(stmt.rows, b.rows[i:end])
+		stmt.columns = make(ColumnList, len(b.columns))
+		copy(stmt.columns, b.columns)
+		stmt.returning = make(returningColumnList, len(b.returning))
+		copy(stmt.returning, b.returning)
+		if b.upsert != nil {
+			stmt.upsert = b.upsert.clone()
+		}
+		stmts = append(stmts, &stmt)
+	}
+	return stmts, nil
+}
+
 // ToSql generates query string, placeholder arguments, and returns err on errors.
 func (b *InsertStatement) ToSql() (query string, args []interface{}, err error) {
-	bldr := newBuilder()
+	return b.ToSqlWith(b.dialect)
+}
+
+// ToSqlWith generates query string, placeholder arguments with the d, and returns err on errors.
+// The default dialect is used if the d is nil.
+func (b *InsertStatement) ToSqlWith(d Dialect) (query string, args []interface{}, err error) {
+	bldr := newBuilderWith(d)
 	defer func() {
 		query, args, err = bldr.Query(), bldr.Args(), bldr.Err()
 	}()
@@ -83,41 +366,182 @@ func (b *InsertStatement) ToSql() (query string, args []interface{}, err error)
 		return
 	}
 
+	// upsert syntax
+	syntax := UpsertOnConflict
+	if b.upsert != nil {
+		opt, e := b.upsert.option()
+		if e != nil {
+			bldr.SetError(e)
+			return
+		}
+		syntax, e = bldr.dialect.UpsertSyntax(opt)
+		if e != nil {
+			bldr.SetError(e)
+			return
+		}
+	}
+
 	// INSERT
-	bldr.Append("INSERT")
+	if b.upsert != nil && syntax == UpsertReplace {
+		bldr.Append("REPLACE")
+	} else {
+		bldr.Append("INSERT")
+	}
+	if b.upsert != nil {
+		switch syntax {
+		case UpsertInsertIgnore:
+			bldr.Append(" IGNORE")
+		case UpsertInsertOrReplace:
+			bldr.Append(" OR REPLACE")
+		}
+	}
 
 	// INTO Table
 	bldr.Append(" INTO ")
 	bldr.AppendItem(b.into)
 
 	// (COLUMN)
-	if len(b.columns) == 0 {
-		b.columns = b.into.Columns()
+	// the statement may be shared by goroutines, so do not write the default columns into it.
+	columns := b.columns
+	if len(columns) == 0 {
+		columns = ColumnList(b.into.Columns())
 	}
 	bldr.Append(" ( ")
-	bldr.AppendItem(b.columns)
+	bldr.AppendItem(columns)
 	bldr.Append(" )")
 
-	// VALUES
-	if len(b.columns) != len(b.values) {
-		bldr.SetError(newError("%d values needed, but got %d.", len(b.columns), len(b.values)))
+	// SELECT
+	if b.source != nil {
+		if len(b.rows) != 0 {
+			bldr.SetError(newError("Select can not be used with VALUES."))
+			return
+		}
+		if n := b.source.columnCount(); n >= 0 && n != len(columns) {
+			bldr.SetError(newError("%d columns needed, but got %d.", len(columns), n))
+			return
+		}
+		if b.source.columnCount() >= 0 {
+			for i, col := range b.source.selectColumns() {
+				if !acceptColumnType(columns[i], col) {
+					bldr.SetError(newError("%s column not accept %s column.",
+						columns[i].config().Type().String(),
+						col.config().Type().String()))
+					return
+				}
+			}
+		}
+		bldr.Append(" ")
+		bldr.AppendItem(b.source)
+	} else {
+		b.serializeValues(bldr, columns)
+	}
+
+	// ON CONFLICT / ON DUPLICATE KEY UPDATE
+	if b.upsert != nil {
+		b.upsert.serialize(bldr, syntax)
+	}
+
+	// RETURNING
+	if len(b.returning) != 0 {
+		bldr.Append(" RETURNING ")
+		bldr.AppendItem(b.returning)
+	}
+	return
+}
+
+func (b *InsertStatement) serializeValues(bldr *builder, columns ColumnList) {
+	if len(b.rows) == 0 {
+		bldr.SetError(newError("%d values needed, but got %d.", len(columns), 0))
 		return
 	}
-	for i := range b.columns {
-		if !b.columns[i].acceptType(b.values[i]) {
-			bldr.SetError(newError("%s column not accept %T.",
-				b.columns[i].config().Type().String(),
-				b.values[i].Raw()))
+	bldr.Append(" VALUES ")
+	for i, row := range b.rows {
+		if len(columns) != len(row) {
+			bldr.SetError(newError("%d values needed, but got %d.", len(columns), len(row)))
 			return
 		}
+		for j := range columns {
+			if !columns[j].acceptType(row[j]) {
+				bldr.SetError(newError("%s column not accept %T.",
+					columns[j].config().Type().String(),
+					row[j].Raw()))
+				return
+			}
+		}
+		if i != 0 {
+			bldr.Append(", ")
+		}
+		bldr.Append("( ")
+		values := make([]serializable, len(row))
+		for j := range values {
+			values[j] = row[j]
+		}
+		bldr.AppendItems(values, ", ")
+		bldr.Append(" )")
 	}
-	bldr.Append(" VALUES ( ")
-	values := make([]serializable, len(b.values))
-	for i := range values {
-		values[i] = b.values[i]
+}
+
+// clone returns a copy of the upsert which does not share slices with the original.
+func (m *upsert) clone() *upsert {
+	u := *m
+	u.target = make(ColumnList, len(m.target))
+	copy(u.target, m.target)
+	u.set = make([]upsertValue, len(m.set))
+	copy(u.set, m.set)
+	return &u
+}
+
+// placeholders returns number of placeholders used in the upsert clause.
+func (m *upsert) placeholders(d Dialect) int {
+	bldr := newBuilderWith(d)
+	m.serialize(bldr, UpsertOnConflict)
+	return len(bldr.args)
+}
+
+func (m *upsert) serialize(bldr *builder, syntax UpsertSyntax) {
+	switch syntax {
+	case UpsertOnConflict:
+		bldr.Append(" ON CONFLICT")
+		if len(m.target) != 0 {
+			bldr.Append(" ( ")
+			bldr.AppendItem(m.target)
+			bldr.Append(" )")
+		}
+		if m.doNothing {
+			bldr.Append(" DO NOTHING")
+			return
+		}
+		bldr.Append(" DO UPDATE SET ")
+	case UpsertOnDuplicateKeyUpdate:
+		bldr.Append(" ON DUPLICATE KEY UPDATE ")
+	default:
+		return
 	}
-	bldr.AppendItems(values, ", ")
-	bldr.Append(" )")
 
-	return
+	for i, v := range m.set {
+		if i != 0 {
+			bldr.Append(", ")
+		}
+		bldr.Append(bldr.QuoteIdent(v.col.column_name()))
+		bldr.Append("=")
+		if !v.excluded {
+			if !v.col.acceptType(v.val) {
+				bldr.SetError(newError("%s column not accept %T.",
+					v.col.config().Type().String(),
+					v.val.Raw(),
+				))
+				return
+			}
+			bldr.AppendItem(v.val)
+		} else if syntax == UpsertOnConflict {
+			bldr.Append("excluded." + bldr.QuoteIdent(v.col.column_name()))
+		} else {
+			bldr.Append("VALUES(" + bldr.QuoteIdent(v.col.column_name()) + ")")
+		}
+	}
+
+	if m.where != nil {
+		bldr.Append(" WHERE ")
+		bldr.AppendItem(m.where)
+	}
 }
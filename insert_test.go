@@ -51,6 +51,70 @@ func TestInsert(t *testing.T) {
 		query:  `INSERT INTO "TABLE_A" ( "id", "str", "bool", "float", "date", "bytes" ) VALUES ( ?, ?, ?, ?, ?, ? );`,
 		args:   []interface{}{int64(1), "hoge", true, 0.1, time.Unix(0, 0).UTC(), []byte{0x01}},
 		errmsg: "",
+	}, {
+		stmt: Insert(table1).
+			Columns(table1.C("id"), table1.C("str")).
+			AddRow(1, "hoge").
+			AddRow(2, "fuga"),
+		query:  `INSERT INTO "TABLE_A" ( "id", "str" ) VALUES ( ?, ? ), ( ?, ? );`,
+		args:   []interface{}{int64(1), "hoge", int64(2), "fuga"},
+		errmsg: "",
+	}, {
+		stmt: Insert(table1).
+			Columns(table1.C("id"), table1.C("str")).
+			Rows([]interface{}{1, "hoge"}, []interface{}{2, "fuga"}, []interface{}{3, "piyo"}),
+		query:  `INSERT INTO "TABLE_A" ( "id", "str" ) VALUES ( ?, ? ), ( ?, ? ), ( ?, ? );`,
+		args:   []interface{}{int64(1), "hoge", int64(2), "fuga", int64(3), "piyo"},
+		errmsg: "",
+	}, {
+		stmt: Insert(table1).
+			Columns(table1.C("id"), table1.C("str")).
+			AddRow(1, "hoge").
+			AddRow(2, 3),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: string column not accept int.",
+	}, {
+		stmt: Insert(table1).
+			Columns(table1.C("id"), table1.C("str")).
+			AddRow(1, "hoge").
+			AddRow(2),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: 2 values needed, but got 1.",
+	}, {
+		stmt: Insert(table1).
+			Rows([]interface{}{1, "hoge"}, []interface{}{2, "fuga"}).
+			Set(table1.C("id"), 1),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: Set can not be used with multiple rows.",
+	}, {
+		stmt: Insert(table1).
+			Set(table1.C("str"), "hoge").
+			Returning(table1.C("id")),
+		query:  `INSERT INTO "TABLE_A" ( "str" ) VALUES ( ? ) RETURNING "id";`,
+		args:   []interface{}{"hoge"},
+		errmsg: "",
+	}, {
+		stmt: Insert(table1).
+			Set(table1.C("str"), "hoge").
+			Returning(Star),
+		query:  `INSERT INTO "TABLE_A" ( "str" ) VALUES ( ? ) RETURNING *;`,
+		args:   []interface{}{"hoge"},
+		errmsg: "",
+	}, {
+		stmt: Insert(table1).
+			Set(table1.C("str"), "hoge").
+			Returning(table2.C("id")),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column not found in table.",
+	}, {
+		stmt:   Insert(table1).Columns(table1.C("id")),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: 1 values needed, but got 0.",
 	}, {
 		stmt:   Insert(table1).Columns(table1.C("id")).Values(1, 2, 3),
 		query:  "",
@@ -80,3 +144,313 @@ func TestInsert(t *testing.T) {
 		}
 	}
 }
+
+func TestInsertChunk(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		StringColumn("str", &ColumnOption{
+			Size: 255,
+		}),
+	)
+
+	stmt := Insert(table1).Columns(table1.C("id"), table1.C("str"))
+	for i := 0; i < 5; i++ {
+		stmt.AddRow(i, "hoge")
+	}
+
+	stmts, err := stmt.Chunk(5)
+	if err != nil {
+		t.Fatalf("failed \ngot %s", err.Error())
+	}
+	var cases = []statementTestCase{{
+		stmt:   stmts[0],
+		query:  `INSERT INTO "TABLE_A" ( "id", "str" ) VALUES ( ?, ? ), ( ?, ? );`,
+		args:   []interface{}{int64(0), "hoge", int64(1), "hoge"},
+		errmsg: "",
+	}, {
+		stmt:   stmts[1],
+		query:  `INSERT INTO "TABLE_A" ( "id", "str" ) VALUES ( ?, ? ), ( ?, ? );`,
+		args:   []interface{}{int64(2), "hoge", int64(3), "hoge"},
+		errmsg: "",
+	}, {
+		stmt:   stmts[2],
+		query:  `INSERT INTO "TABLE_A" ( "id", "str" ) VALUES ( ?, ? );`,
+		args:   []interface{}{int64(4), "hoge"},
+		errmsg: "",
+	}}
+	if len(stmts) != len(cases) {
+		t.Fatalf("failed \nexpect %d statements but got %d", len(cases), len(stmts))
+	}
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+
+	// use dialect's limit
+	stmts, err = stmt.Chunk(0)
+	if err != nil || len(stmts) != 1 {
+		t.Errorf("failed \ngot %d statements", len(stmts))
+	}
+
+	// a row exceeds the limit
+	_, err = stmt.Chunk(1)
+	if err == nil || err.Error() != "sqlbuilder: a row needs 2 placeholders, but limit is 1." {
+		t.Errorf("failed \ngot %v", err)
+	}
+
+	// chunks do not share rows with the original
+	stmts, err = stmt.Chunk(4)
+	if err != nil {
+		t.Fatalf("failed \ngot %s", err.Error())
+	}
+	stmts[0].AddRow(99, "fuga")
+	_, args, err := stmt.ToSql()
+	if err != nil || len(args) != 10 || args[4] != int64(2) {
+		t.Errorf("failed \noriginal statement was changed to %v", args)
+	}
+
+	// placeholders in the upsert clause
+	upsert := Insert(table1).Columns(table1.C("id"), table1.C("str")).
+		OnConflict(table1.C("id")).
+		DoUpdate(table1.C("str"), "fuga")
+	for i := 0; i < 3; i++ {
+		upsert.AddRow(i, "hoge")
+	}
+	stmts, err = upsert.Chunk(5)
+	if err != nil {
+		t.Fatalf("failed \ngot %s", err.Error())
+	}
+	cases = []statementTestCase{{
+		stmt:   stmts[0],
+		query:  `INSERT INTO "TABLE_A" ( "id", "str" ) VALUES ( ?, ? ), ( ?, ? ) ON CONFLICT ( "id" ) DO UPDATE SET "str"=?;`,
+		args:   []interface{}{int64(0), "hoge", int64(1), "hoge", "fuga"},
+		errmsg: "",
+	}, {
+		stmt:   stmts[1],
+		query:  `INSERT INTO "TABLE_A" ( "id", "str" ) VALUES ( ?, ? ) ON CONFLICT ( "id" ) DO UPDATE SET "str"=?;`,
+		args:   []interface{}{int64(2), "hoge", "fuga"},
+		errmsg: "",
+	}}
+	if len(stmts) != len(cases) {
+		t.Fatalf("failed \nexpect %d statements but got %d", len(cases), len(stmts))
+	}
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+
+	_, err = upsert.Chunk(2)
+	if err == nil || err.Error() != "sqlbuilder: a row needs 3 placeholders, but limit is 2." {
+		t.Errorf("failed \ngot %v", err)
+	}
+}
+
+func TestInsertUpsert(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		StringColumn("str", &ColumnOption{
+			Size: 255,
+		}),
+		IntColumn("count", nil),
+	)
+
+	var cases = []statementTestCase{{
+		stmt: Insert(table1).
+			Values(1, "hoge", 1).
+			OnConflict(table1.C("id")).
+			DoNothing(),
+		query:  `INSERT INTO "TABLE_A" ( "id", "str", "count" ) VALUES ( ?, ?, ? ) ON CONFLICT ( "id" ) DO NOTHING;`,
+		args:   []interface{}{int64(1), "hoge", int64(1)},
+		errmsg: "",
+	}, {
+		stmt: Insert(table1).
+			Values(1, "hoge", 1).
+			OnConflict(table1.C("id")).
+			DoUpdateExcluded(table1.C("str")).
+			DoUpdate(table1.C("count"), 0).
+			DoUpdateWhere(table1.C("count").Lt(10)),
+		query: `INSERT INTO "TABLE_A" ( "id", "str", "count" ) VALUES ( ?, ?, ? ) ` +
+			`ON CONFLICT ( "id" ) DO UPDATE SET "str"=excluded."str", "count"=? WHERE "TABLE_A"."count"<?;`,
+		args:   []interface{}{int64(1), "hoge", int64(1), int64(0), int64(10)},
+		errmsg: "",
+	}, {
+		stmt: Insert(table1).
+			Values(1, "hoge", 1).
+			OrReplace(),
+		query:  `INSERT OR REPLACE INTO "TABLE_A" ( "id", "str", "count" ) VALUES ( ?, ?, ? );`,
+		args:   []interface{}{int64(1), "hoge", int64(1)},
+		errmsg: "",
+	}, {
+		stmt: Insert(table1).
+			Values(1, "hoge", 1).
+			DoUpdateExcluded(table1.C("str")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "dialects: ON CONFLICT DO UPDATE needs conflict target",
+	}, {
+		stmt: Insert(table1).
+			Values(1, "hoge", 1).
+			OnConflict(table1.C("id")).
+			DoUpdate(table1.C("count"), "hoge"),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: int column not accept string.",
+	}, {
+		stmt: Insert(table1).
+			Values(1, "hoge", 1).
+			OnConflict(table1.C("id")).
+			DoNothing().
+			DoUpdate(table1.C("count"), 0),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: DoNothing and DoUpdate can not be used together.",
+	}, {
+		stmt: Insert(table1).
+			Values(1, "hoge", 1).
+			OnConflict(table1.C("id")).
+			OrReplace(),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: OrReplace can not be used with OnConflict, DoNothing or DoUpdate.",
+	}, {
+		stmt: Insert(table1).
+			Values(1, "hoge", 1).
+			OnConflict(table1.C("id")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: DoNothing or DoUpdate is needed for upsert.",
+	}, {
+		stmt: Insert(table1).
+			Values(1, "hoge", 1).
+			OnConflict(table1.C("id")).
+			DoNothing().
+			DoUpdateWhere(table1.C("count").Lt(10)),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: DoUpdateWhere needs DoUpdate.",
+	}, {
+		stmt: Insert(table1).
+			Values(1, "hoge", 1).
+			OnConflict(table1.C("id")).
+			DoUpdate(table1.C("count"), 0).
+			DoUpdateWhere(nil),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: condition is nil.",
+	}, {
+		stmt: Insert(table1).
+			Values(1, "hoge", 1).
+			OnConflict(table1.C("invalid")).
+			DoNothing(),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column not found in table.",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
+
+func TestInsertSelect(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		StringColumn("str", &ColumnOption{
+			Size: 255,
+		}),
+	)
+	table2 := NewTable(
+		"TABLE_B",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		StringColumn("name", &ColumnOption{
+			Size: 255,
+		}),
+		IntColumn("age", nil),
+	)
+
+	var cases = []statementTestCase{{
+		stmt: Insert(table1).
+			Columns(table1.C("id"), table1.C("str")).
+			Select(Select(table2).
+				Columns(table2.C("id"), table2.C("name")).
+				Where(table2.C("age").Gt(20))),
+		query:  `INSERT INTO "TABLE_A" ( "id", "str" ) SELECT "TABLE_B"."id", "TABLE_B"."name" FROM "TABLE_B" WHERE "TABLE_B"."age">?;`,
+		args:   []interface{}{int64(20)},
+		errmsg: "",
+	}, {
+		stmt: Insert(table1).
+			Columns(table1.C("id"), table1.C("str")).
+			Select(Select(table2).
+				Columns(table2.C("id"), table2.C("name")).
+				Where(table2.C("age").Gt(20))).
+			OnConflict(table1.C("id")).
+			DoUpdate(table1.C("str"), "hoge"),
+		query: `INSERT INTO "TABLE_A" ( "id", "str" ) SELECT "TABLE_B"."id", "TABLE_B"."name" FROM "TABLE_B" WHERE "TABLE_B"."age">? ` +
+			`ON CONFLICT ( "id" ) DO UPDATE SET "str"=?;`,
+		args:   []interface{}{int64(20), "hoge"},
+		errmsg: "",
+	}, {
+		stmt: Insert(table1).
+			Columns(table1.C("id"), table1.C("str")).
+			Select(Select(table2).Columns(table2.C("id"))),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: 2 columns needed, but got 1.",
+	}, {
+		stmt: Insert(table1).
+			Select(Select(table2)),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: 2 columns needed, but got 3.",
+	}, {
+		stmt: Insert(table1).
+			Columns(table1.C("id"), table1.C("str")).
+			Select(Select(table2).Columns(table2.C("name"), table2.C("id"))),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: int column not accept string column.",
+	}, {
+		stmt: Insert(table1).
+			Columns(table1.C("id"), table1.C("str")).
+			Values(1, "hoge").
+			Select(Select(table2).Columns(table2.C("id"), table2.C("name"))),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: Select can not be used with VALUES.",
+	}, {
+		stmt: Insert(table1).
+			Select(nil),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: SELECT statement is nil.",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
@@ -36,6 +36,14 @@ func toLiteral(v interface{}) literal {
 	}
 }
 
+func toLiterals(vs []interface{}) []literal {
+	l := make([]literal, len(vs))
+	for i := range vs {
+		l[i] = toLiteral(vs[i])
+	}
+	return l
+}
+
 func (l *literalImpl) serialize(bldr *builder) {
 	val, err := l.converted()
 	if err != nil {
@@ -1,7 +1,22 @@
 package sqlbuilder
 
+// QueryCapability represents syntax which a dialect accepts in queries.
+type QueryCapability int
+
+const (
+	// WITH RECURSIVE.  The RECURSIVE keyword is omitted without this.
+	QueryRecursiveKeyword QueryCapability = 1 << iota
+	// ( SELECT ... ) AS alias.  The AS keyword is omitted without this.
+	QueryTableAliasAs
+	// EXCEPT operator.  MINUS operator is used without this.
+	QueryExceptOperator
+	// left % right.  MOD(left, right) function is used without this.
+	QueryModOperator
+)
+
 // SelectStatement represents a SELECT statement.
 type SelectStatement struct {
+	with     *WithClause
 	columns  selectColumnList
 	from     Table
 	where    Condition
@@ -11,10 +26,96 @@ type SelectStatement struct {
 	limit    int
 	offset   int
 	having   Condition
+	windows  []*Window
+	lock     *LockOption
+
+	dialect Dialect
 
 	err error
 }
 
+// LockMode represents a mode of row locking clause.
+type LockMode int
+
+const (
+	LockForUpdate LockMode = iota
+	LockForShare
+)
+
+func (m LockMode) String() string {
+	switch m {
+	case LockForUpdate:
+		return "FOR UPDATE"
+	case LockForShare:
+		return "FOR SHARE"
+	}
+	return ""
+}
+
+// LockOption represents row locking clause for SELECT statement. ex: FOR UPDATE.
+// Dialects handle this for know locking options.
+type LockOption struct {
+	Mode       LockMode
+	Of         []string
+	NoWait     bool
+	SkipLocked bool
+}
+
+// PagingSyntax represents a syntax of LIMIT/OFFSET which a dialect uses.
+type PagingSyntax int
+
+const (
+	// LIMIT n OFFSET m
+	PagingLimitOffset PagingSyntax = iota
+	// OFFSET m ROWS FETCH NEXT n ROWS ONLY
+	PagingOffsetFetch
+)
+
+// PagingOption represents LIMIT/OFFSET of a statement.
+// Dialects handle this for choose its syntax.
+type PagingOption struct {
+	Limit   int
+	Offset  int
+	OrderBy bool
+}
+
+// serializePaging serializes LIMIT/OFFSET clause by the dialect's syntax.
+func serializePaging(bldr *builder, opt *PagingOption) {
+	if opt.Limit == 0 && opt.Offset == 0 {
+		return
+	}
+	syntax, err := bldr.dialect.PagingSyntax(opt)
+	if err != nil {
+		bldr.SetError(err)
+		return
+	}
+
+	switch syntax {
+	case PagingLimitOffset:
+		if opt.Limit != 0 {
+			bldr.Append(" LIMIT ")
+			bldr.AppendValue(opt.Limit)
+		}
+		if opt.Offset != 0 {
+			bldr.Append(" OFFSET ")
+			bldr.AppendValue(opt.Offset)
+		}
+	case PagingOffsetFetch:
+		bldr.Append(" OFFSET ")
+		if opt.Offset != 0 {
+			bldr.AppendValue(opt.Offset)
+		} else {
+			bldr.Append("0")
+		}
+		bldr.Append(" ROWS")
+		if opt.Limit != 0 {
+			bldr.Append(" FETCH NEXT ")
+			bldr.AppendValue(opt.Limit)
+			bldr.Append(" ROWS ONLY")
+		}
+	}
+}
+
 // Select returns new SELECT statement with from as FROM clause.
 func Select(from Table) *SelectStatement {
 	if from == nil {
@@ -45,15 +146,15 @@ func (b *SelectStatement) Columns(columns ...Column) *SelectStatement {
 }
 
 // Where sets WHERE clause.  The cond is filter condition.
+// Columns in the cond are checked on serializing, so the cond can refer columns of outer queries
+// when the statement is used as a subquery.
 func (b *SelectStatement) Where(cond Condition) *SelectStatement {
 	if b.err != nil {
 		return b
 	}
-	for _, col := range cond.columns() {
-		if !b.from.hasColumn(col) {
-			b.err = newError("column not found in FROM.")
-			return b
-		}
+	if cond == nil {
+		b.err = newError("condition is nil.")
+		return b
 	}
 
 	b.where = cond
@@ -91,6 +192,70 @@ func (b *SelectStatement) Having(cond Condition) *SelectStatement {
 	return b
 }
 
+// Window sets "WINDOW" clause by the windows.  The windows must be created by NewNamedWindow().
+func (b *SelectStatement) Window(windows ...*Window) *SelectStatement {
+	if b.err != nil {
+		return b
+	}
+	for _, w := range windows {
+		if w == nil {
+			b.err = newError("window is nil.")
+			return b
+		}
+		if len(w.name) == 0 {
+			b.err = newError("window in WINDOW clause needs name.")
+			return b
+		}
+		if b.findWindow(w.name) != nil {
+			b.err = newError("window %s was duplicated.", w.name)
+			return b
+		}
+		for _, col := range w.columns() {
+			if !b.from.hasColumn(col) {
+				b.err = newError("column not found in FROM.")
+				return b
+			}
+		}
+		b.windows = append(b.windows, w)
+	}
+	return b
+}
+
+// checkWindowRefs returns error if the columns refer a window which is not defined in WINDOW clause.
+func (b *SelectStatement) checkWindowRefs() error {
+	cols := make([]Column, 0, len(b.columns)+len(b.orderBy))
+	for _, col := range b.columns {
+		cols = append(cols, col)
+	}
+	for _, o := range b.orderBy {
+		if ob, ok := o.(*orderBy); ok {
+			cols = append(cols, ob.column)
+		}
+	}
+	for _, col := range cols {
+		if acol, ok := col.(*aliasColumn); ok {
+			col = acol.column
+		}
+		wf, ok := col.(*windowFunc)
+		if !ok || wf.window == nil || len(wf.window.name) == 0 {
+			continue
+		}
+		if b.findWindow(wf.window.name) == nil {
+			return newError("window %s is not defined.", wf.window.name)
+		}
+	}
+	return nil
+}
+
+func (b *SelectStatement) findWindow(name string) *Window {
+	for _, w := range b.windows {
+		if w.name == name {
+			return w
+		}
+	}
+	return nil
+}
+
 // OrderBy sets "ORDER BY" clause. Use descending order if the desc is true, by the columns.
 func (b *SelectStatement) OrderBy(desc bool, columns ...Column) *SelectStatement {
 	if b.err != nil {
@@ -124,12 +289,81 @@ func (b *SelectStatement) Offset(offset int) *SelectStatement {
 	return b
 }
 
+// ForUpdate sets "FOR UPDATE" clause.  Lock only rows from the tables if tables are given.
+func (b *SelectStatement) ForUpdate(tables ...Table) *SelectStatement {
+	return b.setLock(LockForUpdate, tables)
+}
+
+// ForShare sets "FOR SHARE" clause.  Lock only rows from the tables if tables are given.
+func (b *SelectStatement) ForShare(tables ...Table) *SelectStatement {
+	return b.setLock(LockForShare, tables)
+}
+
+func (b *SelectStatement) setLock(mode LockMode, tables []Table) *SelectStatement {
+	if b.err != nil {
+		return b
+	}
+	of := make([]string, 0, len(tables))
+	for _, tbl := range tables {
+		if tbl == nil || !containsTable(b.from, tbl) {
+			b.err = newError("table not found in FROM.")
+			return b
+		}
+		of = append(of, tbl.Name())
+	}
+	b.lock = &LockOption{
+		Mode: mode,
+		Of:   of,
+	}
+	return b
+}
+
+// NoWait sets "NOWAIT" option to locking clause.  Call after ForUpdate() or ForShare().
+func (b *SelectStatement) NoWait() *SelectStatement {
+	if b.err != nil {
+		return b
+	}
+	if b.lock == nil {
+		b.err = newError("locking clause is not set.")
+		return b
+	}
+	b.lock.NoWait = true
+	return b
+}
+
+// SkipLocked sets "SKIP LOCKED" option to locking clause.  Call after ForUpdate() or ForShare().
+func (b *SelectStatement) SkipLocked() *SelectStatement {
+	if b.err != nil {
+		return b
+	}
+	if b.lock == nil {
+		b.err = newError("locking clause is not set.")
+		return b
+	}
+	b.lock.SkipLocked = true
+	return b
+}
+
 func (b *SelectStatement) serialize(bldr *builder) {
 	if b.err != nil {
 		bldr.SetError(b.err)
 		return
 	}
 
+	// WITH
+	if b.with != nil {
+		bldr.AppendItem(b.with)
+	}
+
+	if err := b.checkWindowRefs(); err != nil {
+		bldr.SetError(err)
+		return
+	}
+
+	// columns in subqueries can refer the FROM, except subqueries in the FROM.
+	bldr.pushScope(b.from)
+	defer bldr.popScope()
+
 	// SELECT COLUMN
 	bldr.Append("SELECT ")
 	if b.distinct {
@@ -139,10 +373,16 @@ func (b *SelectStatement) serialize(bldr *builder) {
 
 	// FROM
 	bldr.Append(" FROM ")
-	bldr.AppendItem(b.from)
+	bldr.AppendItemWithoutScopes(b.from)
 
 	// WHERE
 	if b.where != nil {
+		for _, col := range b.where.columns() {
+			if !bldr.hasColumn(b.from, col) {
+				bldr.SetError(newError("column not found in FROM."))
+				return
+			}
+		}
 		bldr.Append(" WHERE ")
 		bldr.AppendItem(b.where)
 	}
@@ -162,44 +402,248 @@ func (b *SelectStatement) serialize(bldr *builder) {
 		bldr.AppendItem(b.having)
 	}
 
+	// WINDOW
+	if len(b.windows) != 0 {
+		bldr.Append(" WINDOW ")
+		for i, w := range b.windows {
+			if i != 0 {
+				bldr.Append(", ")
+			}
+			w.serializeDefinition(bldr)
+		}
+	}
+
 	// ORDER BY
 	if b.orderBy != nil {
 		bldr.Append(" ORDER BY ")
 		bldr.AppendItems(b.orderBy, ", ")
 	}
 
-	// LIMIT
-	if b.limit != 0 {
-		bldr.Append(" LIMIT ")
-		bldr.AppendValue(b.limit)
-	}
+	// LIMIT / OFFSET
+	serializePaging(bldr, &PagingOption{
+		Limit:   b.limit,
+		Offset:  b.offset,
+		OrderBy: b.orderBy != nil,
+	})
 
-	// Offset
-	if b.offset != 0 {
-		bldr.Append(" OFFSET ")
-		bldr.AppendValue(b.offset)
+	// FOR UPDATE / FOR SHARE
+	if b.lock != nil {
+		for _, name := range b.lock.Of {
+			if !bldr.CheckIdent(name) {
+				return
+			}
+		}
+		if str, err := bldr.dialect.LockOptionToString(b.lock); err == nil {
+			if len(str) != 0 {
+				bldr.Append(" " + str)
+			}
+		} else {
+			bldr.SetError(err)
+		}
 	}
 	return
 }
 
+// columnCount returns number of columns in result.  returns -1 if it is unknown.
+func (b *SelectStatement) columnCount() int {
+	if b.from == nil {
+		return -1
+	}
+	if len(b.columns) == 0 {
+		if n := len(b.from.Columns()); n != 0 {
+			return n
+		}
+		return -1
+	}
+	for _, col := range b.columns {
+		if col == Star {
+			return -1
+		}
+	}
+	return len(b.columns)
+}
+
 // ToSql generates query string, placeholder arguments, and returns err on errors.
 func (b *SelectStatement) ToSql() (query string, args []interface{}, err error) {
-	bldr := newBuilder()
+	return b.ToSqlWith(b.dialect)
+}
+
+// ToSqlWith generates query string, placeholder arguments with the d, and returns err on errors.
+// The default dialect is used if the d is nil.
+func (b *SelectStatement) ToSqlWith(d Dialect) (query string, args []interface{}, err error) {
+	bldr := newBuilderWith(d)
 	bldr.AppendItem(b)
 	return bldr.Query(), bldr.Args(), bldr.Err()
 }
 
+// ToSubquery returns a Table which uses the statement as a subquery named by the alias.
 func (m *SelectStatement) ToSubquery(alias string) Table {
 	return newSubquery(m, alias)
 }
 
+func (m *SelectStatement) selectColumns() selectColumnList {
+	if len(m.columns) == 0 && m.from != nil {
+		return m.from.Columns()
+	}
+	return m.columns
+}
+
+// ToColumn returns a Column which uses the statement as a scalar subquery.
+// The statement must select just one column.
+func (m *SelectStatement) ToColumn() Column {
+	return newScalarSubquery(m)
+}
+
+// selectable is a statement which can be used as a subquery.
+type selectable interface {
+	serializable
+
+	selectColumns() selectColumnList
+}
+
+// selectColumnCount returns number of columns in result of the s.  returns -1 if it is unknown.
+func selectColumnCount(s selectable) int {
+	cols := s.selectColumns()
+	if len(cols) == 0 {
+		return -1
+	}
+	for _, col := range cols {
+		if col == Star {
+			return -1
+		}
+	}
+	return len(cols)
+}
+
+// scalarSubquery is a subquery which returns a single value.  This can be use in the same way as Column.
+type scalarSubquery struct {
+	stat selectable
+}
+
+func newScalarSubquery(stat selectable) *scalarSubquery {
+	// typed nil is handled as nil.
+	switch t := stat.(type) {
+	case *SelectStatement:
+		if t == nil {
+			stat = nil
+		}
+	case *CompoundStatement:
+		if t == nil {
+			stat = nil
+		}
+	}
+	return &scalarSubquery{
+		stat: stat,
+	}
+}
+
+func (m *scalarSubquery) serialize(bldr *builder) {
+	if m.stat == nil {
+		bldr.SetError(newError("subquery is nil."))
+		return
+	}
+	if n := selectColumnCount(m.stat); n != -1 && n != 1 {
+		bldr.SetError(newError("scalar subquery needs just one column, but got %d.", n))
+		return
+	}
+	bldr.Append("( ")
+	bldr.AppendItem(m.stat)
+	bldr.Append(" )")
+}
+
+func (m *scalarSubquery) As(alias string) Column {
+	return &aliasColumn{
+		column: m,
+		alias:  alias,
+	}
+}
+
+func (m *scalarSubquery) column_name() string {
+	return ""
+}
+
+func (m *scalarSubquery) config() ColumnConfig {
+	typ := ColumnTypeAny
+	if m.stat != nil && selectColumnCount(m.stat) == 1 {
+		if cc := m.stat.selectColumns()[0].config(); cc != nil {
+			typ = cc.Type()
+		}
+	}
+	return newColumnConfigImpl("", typ, nil)
+}
+
+func (m *scalarSubquery) acceptType(val interface{}) bool {
+	return acceptLiteralType(m.config().Type(), false, val)
+}
+
+// columns returns no column.  Columns in the subquery are checked by the subquery itself.
+func (m *scalarSubquery) columns() []Column {
+	return []Column{}
+}
+
+func (left *scalarSubquery) Eq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "=")
+}
+
+func (left *scalarSubquery) NotEq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "<>")
+}
+
+func (left *scalarSubquery) Gt(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, ">")
+}
+
+func (left *scalarSubquery) GtEq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, ">=")
+}
+
+func (left *scalarSubquery) Lt(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "<")
+}
+
+func (left *scalarSubquery) LtEq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "<=")
+}
+
+func (left *scalarSubquery) Like(right string) Condition {
+	return newBinaryOperationCondition(left, right, " LIKE ")
+}
+
+func (left *scalarSubquery) NotLike(right string) Condition {
+	return newBinaryOperationCondition(left, right, " NOT LIKE ")
+}
+
+func (left *scalarSubquery) Between(lower, higher interface{}) Condition {
+	return newBetweenCondition(left, lower, higher)
+}
+
+func (left *scalarSubquery) NotBetween(lower, higher interface{}) Condition {
+	return newNotBetweenCondition(left, lower, higher)
+}
+
+func (left *scalarSubquery) In(vals ...interface{}) Condition {
+	return newInCondition(left, vals...)
+}
+
+func (left *scalarSubquery) NotIn(vals ...interface{}) Condition {
+	return newNotInCondition(left, vals...)
+}
+
+func (left *scalarSubquery) IsNull() Condition {
+	return newNullCondition(left, false)
+}
+
+func (left *scalarSubquery) IsNotNull() Condition {
+	return newNullCondition(left, true)
+}
+
 type subquery struct {
-	stat  *SelectStatement
+	stat  selectable
 	alias string
 	err   error
 }
 
-func newSubquery(s *SelectStatement, alias string) *subquery {
+func newSubquery(s selectable, alias string) *subquery {
 	m := &subquery{
 		stat:  s,
 		alias: alias,
@@ -218,7 +662,11 @@ func (m *subquery) serialize(bldr *builder) {
 
 	bldr.Append("( ")
 	bldr.AppendItem(m.stat)
-	bldr.Append(" ) AS " + m.alias)
+	if bldr.dialect.QueryCapabilities()&QueryTableAliasAs != 0 {
+		bldr.Append(" ) AS " + bldr.QuoteIdent(m.alias))
+	} else {
+		bldr.Append(" ) " + bldr.QuoteIdent(m.alias))
+	}
 	return
 }
 
@@ -227,22 +675,28 @@ func (m *subquery) Name() string {
 }
 
 func (m *subquery) C(name string) Column {
-	for _, col := range m.stat.columns {
+	if len(name) == 0 {
+		return newErrorColumn(newError("column name is empty."))
+	}
+	for _, col := range m.stat.selectColumns() {
 		if ac, ok := col.(aliasedColumn); ok {
 			if ac.column_alias() == name {
-				return col.config().toColumn(m)
+				return newColumnConfigImpl(name, argType(col), nil).toColumn(m)
 			}
 		}
 		if col.column_name() == name {
-			return col.config().toColumn(m)
+			if cc := col.config(); cc != nil && cc.Name() == name {
+				return cc.toColumn(m)
+			}
+			return newColumnConfigImpl(name, argType(col), nil).toColumn(m)
 		}
 	}
 	return newErrorColumn(newError("column %s was not found.", name))
 }
 
 func (m *subquery) Columns() []Column {
-	l := make([]Column, len(m.stat.columns))
-	for _, col := range m.stat.columns {
+	l := make([]Column, len(m.stat.selectColumns()))
+	for _, col := range m.stat.selectColumns() {
 		if _, ok := col.(aliasedColumn); ok {
 			l = append(l, col.config().toColumn(m))
 		}
@@ -283,7 +737,7 @@ func (m *subquery) hasColumn(trg Column) bool {
 		if cimpl.table != m {
 			return false
 		}
-		for _, col := range m.stat.columns {
+		for _, col := range m.stat.selectColumns() {
 			if col.column_name() == trg.column_name() {
 				return true
 			}
@@ -291,25 +745,11 @@ func (m *subquery) hasColumn(trg Column) bool {
 		return false
 	}
 	if acol, ok := trg.(*aliasColumn); ok {
-		if acol.column.(*columnImpl).table != m {
-			return false
-		}
-		for _, col := range m.stat.columns {
-			if col.column_name() == trg.column_name() {
-				return true
-			}
-		}
-		return false
+		return m.hasColumn(acol.column)
 	}
-	if sqlfn, ok := trg.(*sqlFuncImpl); ok {
-		for _, fncol := range sqlfn.columns() {
-			find := false
-			for _, col := range m.stat.columns {
-				if col.column_name() == fncol.column_name() {
-					find = true
-				}
-			}
-			if !find {
+	if ccol, ok := trg.(compositeColumn); ok {
+		for _, col := range ccol.columns() {
+			if !m.hasColumn(col) {
 				return false
 			}
 		}
@@ -104,6 +104,76 @@ func TestSelect(t *testing.T) {
 	}
 }
 
+func TestSelectLock(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("test1", nil),
+	)
+	table2 := NewTable(
+		"TABLE_B",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+	)
+	table3 := NewTable(
+		"TABLE_C",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+	)
+	tableJoined := table1.InnerJoin(table2, table1.C("test1").Eq(table2.C("id")))
+
+	var cases = []statementTestCase{{
+		stmt:   Select(table1).Where(table1.C("id").Eq(1)).ForUpdate(),
+		query:  `SELECT * FROM "TABLE_A" WHERE "TABLE_A"."id"=? FOR UPDATE;`,
+		args:   []interface{}{int64(1)},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Limit(10).ForUpdate().SkipLocked(),
+		query:  `SELECT * FROM "TABLE_A" LIMIT ? FOR UPDATE SKIP LOCKED;`,
+		args:   []interface{}{10},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).ForShare().NoWait(),
+		query:  `SELECT * FROM "TABLE_A" FOR SHARE NOWAIT;`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   Select(tableJoined).Columns(table1.C("id")).ForUpdate(table1),
+		query:  `SELECT "TABLE_A"."id" FROM "TABLE_A" INNER JOIN "TABLE_B" ON "TABLE_A"."test1"="TABLE_B"."id" FOR UPDATE OF "TABLE_A";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   Select(tableJoined).ForUpdate(table3),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: table not found in FROM.",
+	}, {
+		stmt:   Select(table1).SkipLocked(),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: locking clause is not set.",
+	}, {
+		stmt:   Select(table1).ForUpdate().NoWait().SkipLocked(),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "dialects: NOWAIT and SKIP LOCKED can not be used together",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
+
 func TestSubquery(t *testing.T) {
 	table1 := NewTable(
 		"TABLE_A",
@@ -120,7 +190,7 @@ func TestSubquery(t *testing.T) {
 		Columns(subquery.C("id")).
 		Where(subquery.C("id").Eq(1)).ToSql()
 
-	if `SELECT "SQ1"."id" FROM ( SELECT "TABLE_A"."id" FROM "TABLE_A" ) AS SQ1 WHERE "SQ1"."id"=?;` != query {
+	if `SELECT "SQ1"."id" FROM ( SELECT "TABLE_A"."id" FROM "TABLE_A" ) AS "SQ1" WHERE "SQ1"."id"=?;` != query {
 		t.Errorf("failed \ngot %s", query)
 	}
 	if !reflect.DeepEqual([]interface{}{int64(1)}, attrs) {
@@ -131,6 +201,125 @@ func TestSubquery(t *testing.T) {
 	}
 }
 
+func TestSubqueryCondition(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("test1", nil),
+	)
+	table2 := NewTable(
+		"TABLE_B",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("a_id", nil),
+		IntColumn("value", nil),
+	)
+	derived := Select(table2).Columns(table2.C("id")).Where(table2.C("value").Gt(1)).ToSubquery("s")
+	correlated := Select(table2).Columns(table2.C("id")).Where(table2.C("id").Eq(table1.C("id"))).ToSubquery("s")
+
+	var cases = []statementTestCase{{
+		stmt: Select(table1).Where(
+			table1.C("id").In(Select(table2).Columns(table2.C("a_id")).Where(table2.C("value").Gt(10))),
+		),
+		query:  `SELECT * FROM "TABLE_A" WHERE "TABLE_A"."id" IN ( SELECT "TABLE_B"."a_id" FROM "TABLE_B" WHERE "TABLE_B"."value">? );`,
+		args:   []interface{}{int64(10)},
+		errmsg: "",
+	}, {
+		stmt: Select(table1).Where(
+			table1.C("id").NotIn(Select(table2).Columns(table2.C("a_id"))),
+		),
+		query:  `SELECT * FROM "TABLE_A" WHERE "TABLE_A"."id" NOT IN ( SELECT "TABLE_B"."a_id" FROM "TABLE_B" );`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Where(table1.C("id").NotIn(1, 2)),
+		query:  `SELECT * FROM "TABLE_A" WHERE "TABLE_A"."id" NOT IN ( ?, ? );`,
+		args:   []interface{}{int64(1), int64(2)},
+		errmsg: "",
+	}, {
+		stmt: Select(table1).Where(And(
+			table1.C("test1").Eq(1),
+			Exists(Select(table2).Where(table2.C("a_id").Eq(table1.C("id")))),
+		)),
+		query:  `SELECT * FROM "TABLE_A" WHERE "TABLE_A"."test1"=? AND EXISTS ( SELECT * FROM "TABLE_B" WHERE "TABLE_B"."a_id"="TABLE_A"."id" );`,
+		args:   []interface{}{int64(1)},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Where(NotExists(Select(table2).Where(table2.C("a_id").Eq(table1.C("id"))))),
+		query:  `SELECT * FROM "TABLE_A" WHERE NOT EXISTS ( SELECT * FROM "TABLE_B" WHERE "TABLE_B"."a_id"="TABLE_A"."id" );`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt: Select(table1).Where(
+			table1.C("test1").Gt(Select(table2).Columns(Func("MAX", table2.C("value"))).Where(table2.C("a_id").Eq(table1.C("id")))),
+		),
+		query:  `SELECT * FROM "TABLE_A" WHERE "TABLE_A"."test1">( SELECT MAX("TABLE_B"."value") FROM "TABLE_B" WHERE "TABLE_B"."a_id"="TABLE_A"."id" );`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt: Select(table1).Columns(
+			table1.C("id"),
+			Select(table2).Columns(Func("COUNT", table2.C("id"))).Where(table2.C("a_id").Eq(table1.C("id"))).ToColumn().As("cnt"),
+		),
+		query:  `SELECT "TABLE_A"."id", ( SELECT COUNT("TABLE_B"."id") FROM "TABLE_B" WHERE "TABLE_B"."a_id"="TABLE_A"."id" ) AS "cnt" FROM "TABLE_A";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   Select(table2).Where(table2.C("a_id").Eq(table1.C("id"))),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column not found in FROM.",
+	}, {
+		stmt:   Select(table1).Where(table1.C("id").In(Select(table2).Columns(table2.C("id"), table2.C("a_id")))),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: subquery for IN needs just one column, but got 2.",
+	}, {
+		stmt:   Select(table1).Where(table1.C("id").Eq(Select(table2))),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: scalar subquery needs just one column, but got 3.",
+	}, {
+		stmt: Select(table1).Where(table1.C("id").Eq(Union(
+			Select(table2).Columns(table2.C("a_id")).Where(table2.C("value").Eq(1)),
+			Select(table2).Columns(table2.C("a_id")).Where(table2.C("value").Eq(2)),
+		))),
+		query: `SELECT * FROM "TABLE_A" WHERE "TABLE_A"."id"=( SELECT "TABLE_B"."a_id" FROM "TABLE_B" WHERE "TABLE_B"."value"=? UNION ` +
+			`SELECT "TABLE_B"."a_id" FROM "TABLE_B" WHERE "TABLE_B"."value"=? );`,
+		args:   []interface{}{int64(1), int64(2)},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Where(table1.C("id").Eq(Union(Select(table2), Select(table2)))),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: scalar subquery needs just one column, but got 3.",
+	}, {
+		// subquery in FROM can not refer outer queries.
+		stmt:   Select(table1).Where(table1.C("id").In(Select(correlated).Columns(correlated.C("id")))),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column not found in FROM.",
+	}, {
+		stmt: Select(table1).Where(table1.C("id").In(Select(derived).Columns(derived.C("id")).Where(derived.C("id").Eq(table1.C("test1"))))),
+		query: `SELECT * FROM "TABLE_A" WHERE "TABLE_A"."id" IN ( SELECT "s"."id" FROM ( SELECT "TABLE_B"."id" FROM "TABLE_B" WHERE "TABLE_B"."value">? ) AS "s" ` +
+			`WHERE "s"."id"="TABLE_A"."test1" );`,
+		args:   []interface{}{int64(1)},
+		errmsg: "",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
+
 func BenchmarkSelect(b *testing.B) {
 	table1 := NewTable(
 		"TABLE_A",
@@ -5,10 +5,16 @@ package sqlbuilder
 
 import (
 	"bytes"
+	sqldriver "database/sql/driver"
 	"fmt"
+	"strings"
+	"sync"
 )
 
-var _dialect Dialect = nil
+var (
+	_dialect    Dialect = nil
+	_dialect_mu sync.RWMutex
+)
 
 // Star reprecents * column.
 var Star Column = &columnImpl{nil, nil}
@@ -16,6 +22,9 @@ var Star Column = &columnImpl{nil, nil}
 // Statement reprecents a statement(SELECT/INSERT/UPDATE and other)
 type Statement interface {
 	ToSql() (query string, attrs []interface{}, err error)
+
+	// ToSqlWith generates query with the dialect instead of the statement's one.
+	ToSqlWith(d Dialect) (query string, attrs []interface{}, err error)
 }
 
 type serializable interface {
@@ -30,32 +39,187 @@ type Dialect interface {
 	ColumnTypeToString(ColumnConfig) (string, error)
 	ColumnOptionToString(*ColumnOption) (string, error)
 	TableOptionToString(*TableOption) (string, error)
+	LockOptionToString(*LockOption) (string, error)
+	MaxPlaceholders() int
+	UpsertSyntax(*UpsertOption) (UpsertSyntax, error)
+	SupportsReturning() bool
+	ConcatOperator() string
+	CastTypeToString(ColumnType) (string, error)
+	LengthFunction() string
+	PagingSyntax(*PagingOption) (PagingSyntax, error)
+	UpdateCapabilities() UpdateCapability
+	MaxIdentifierLength() int
+	ForeignKeyActionToString(*ForeignKey) (string, error)
+	LiteralToString(interface{}) (string, error)
+	IndexCapabilities() IndexCapability
+	DropTableOptionToString(*DropTableOption) (string, error)
+	TruncateSyntax(*TruncateOption) (TruncateSyntax, error)
+	AlterTableCapabilities() AlterTableCapability
+	QueryCapabilities() QueryCapability
 }
 
-// SetDialect sets dialect for SQL server.
-// Must set dialect at first.
+// SetDialect sets default dialect for SQL server.
+// Must set dialect at first, or create statements from the Builder(see New()).
 func SetDialect(opt Dialect) {
+	_dialect_mu.Lock()
+	defer _dialect_mu.Unlock()
 	_dialect = opt
 }
 
 func dialect() Dialect {
+	_dialect_mu.RLock()
+	defer _dialect_mu.RUnlock()
 	if _dialect == nil {
 		panic(newError("dialect is not setted.  Call SetDialect() first."))
 	}
 	return _dialect
 }
 
+// Builder creates statements which use the dialect instead of the default one set by SetDialect().
+// Builder is safe for concurrent use.
+type Builder struct {
+	dialect Dialect
+}
+
+// New returns new Builder with the dialect.
+func New(d Dialect) *Builder {
+	return &Builder{
+		dialect: d,
+	}
+}
+
+// Select returns new SELECT statement with from as FROM clause.
+func (m *Builder) Select(from Table) *SelectStatement {
+	s := Select(from)
+	s.dialect = m.dialect
+	return s
+}
+
+// Insert returns new INSERT statement.
+func (m *Builder) Insert(into Table) *InsertStatement {
+	s := Insert(into)
+	s.dialect = m.dialect
+	return s
+}
+
+// Update returns new UPDATE statement.
+func (m *Builder) Update(tbl Table) *UpdateStatement {
+	s := Update(tbl)
+	s.dialect = m.dialect
+	return s
+}
+
+// Delete returns new DELETE statement.
+func (m *Builder) Delete(from Table) *DeleteStatement {
+	s := Delete(from)
+	s.dialect = m.dialect
+	return s
+}
+
+// CreateTable returns new CREATE TABLE statement.
+func (m *Builder) CreateTable(tbl Table) *CreateTableStatement {
+	s := CreateTable(tbl)
+	s.dialect = m.dialect
+	return s
+}
+
+// CreateIndex returns new CREATE INDEX statement.
+func (m *Builder) CreateIndex(tbl Table) *CreateIndexStatement {
+	s := CreateIndex(tbl)
+	s.dialect = m.dialect
+	return s
+}
+
+// DropTable returns new DROP TABLE statement.
+func (m *Builder) DropTable(tbls ...Table) *DropTableStatement {
+	s := DropTable(tbls...)
+	s.dialect = m.dialect
+	return s
+}
+
+// Truncate returns new TRUNCATE TABLE statement.
+func (m *Builder) Truncate(tbls ...Table) *TruncateStatement {
+	s := Truncate(tbls...)
+	s.dialect = m.dialect
+	return s
+}
+
+// DropIndex returns new DROP INDEX statement.
+func (m *Builder) DropIndex(tbl Table, name string) *DropIndexStatement {
+	s := DropIndex(tbl, name)
+	s.dialect = m.dialect
+	return s
+}
+
+// AlterTable returns new ALTER TABLE statement.
+func (m *Builder) AlterTable(tbl Table) *AlterTableStatement {
+	s := AlterTable(tbl)
+	s.dialect = m.dialect
+	return s
+}
+
+// Union returns new compound statement with "UNION" operator.
+func (m *Builder) Union(selects ...*SelectStatement) *CompoundStatement {
+	s := Union(selects...)
+	s.dialect = m.dialect
+	return s
+}
+
+// UnionAll returns new compound statement with "UNION ALL" operator.
+func (m *Builder) UnionAll(selects ...*SelectStatement) *CompoundStatement {
+	s := UnionAll(selects...)
+	s.dialect = m.dialect
+	return s
+}
+
+// Intersect returns new compound statement with "INTERSECT" operator.
+func (m *Builder) Intersect(selects ...*SelectStatement) *CompoundStatement {
+	s := Intersect(selects...)
+	s.dialect = m.dialect
+	return s
+}
+
+// Except returns new compound statement with "EXCEPT" operator.
+func (m *Builder) Except(selects ...*SelectStatement) *CompoundStatement {
+	s := Except(selects...)
+	s.dialect = m.dialect
+	return s
+}
+
+// With returns new WITH clause with the ctes.
+func (m *Builder) With(ctes ...*CTE) *WithClause {
+	w := With(ctes...)
+	w.dialect = m.dialect
+	return w
+}
+
 type builder struct {
-	query *bytes.Buffer
-	args  []interface{}
-	err   error
+	query   *bytes.Buffer
+	args    []interface{}
+	err     error
+	scopes  []Table
+	dialect Dialect
+
+	// constraint is true while serializing a table constraint.
+	// Values are written as literals, and columns are not qualified by table name.
+	constraint bool
 }
 
+// newBuilder returns new builder with the default dialect.
 func newBuilder() *builder {
+	return newBuilderWith(nil)
+}
+
+// newBuilderWith returns new builder with the d.  Use the default dialect if the d is nil.
+func newBuilderWith(d Dialect) *builder {
+	if d == nil {
+		d = dialect()
+	}
 	return &builder{
-		query: bytes.NewBuffer(make([]byte, 0, 256)),
-		args:  make([]interface{}, 0, 8),
-		err:   nil,
+		query:   bytes.NewBuffer(make([]byte, 0, 256)),
+		args:    make([]interface{}, 0, 8),
+		err:     nil,
+		dialect: d,
 	}
 }
 
@@ -70,7 +234,7 @@ func (b *builder) Query() string {
 	if b.err != nil {
 		return ""
 	}
-	return b.query.String() + dialect().QuerySuffix()
+	return b.query.String() + b.dialect.QuerySuffix()
 }
 
 func (b *builder) Args() []interface{} {
@@ -88,6 +252,48 @@ func (b *builder) SetError(err error) {
 	return
 }
 
+// pushScope adds the tbl to tables of outer queries.  Columns in the tbl can be referred from subqueries.
+func (b *builder) pushScope(tbl Table) {
+	b.scopes = append(b.scopes, tbl)
+}
+
+// popScope removes the last table added by pushScope.
+func (b *builder) popScope() {
+	b.scopes = b.scopes[:len(b.scopes)-1]
+}
+
+// AppendItemWithoutScopes appends the item which can not refer tables of outer queries(ex: subquery in FROM).
+func (b *builder) AppendItemWithoutScopes(item serializable) {
+	scopes := b.scopes
+	b.scopes = nil
+	b.AppendItem(item)
+	b.scopes = scopes
+}
+
+// hasColumn returns true if the col belongs to the from or tables of outer queries.
+func (b *builder) hasColumn(from Table, col Column) bool {
+	if from.hasColumn(col) {
+		return true
+	}
+	if acol, ok := col.(*aliasColumn); ok {
+		return b.hasColumn(from, acol.column)
+	}
+	if ccol, ok := col.(compositeColumn); ok {
+		for _, c := range ccol.columns() {
+			if !b.hasColumn(from, c) {
+				return false
+			}
+		}
+		return true
+	}
+	for i := len(b.scopes) - 1; i >= 0; i-- {
+		if b.scopes[i].hasColumn(col) {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *builder) Append(query string) {
 	if b.err != nil {
 		return
@@ -96,16 +302,60 @@ func (b *builder) Append(query string) {
 	b.query.WriteString(query)
 }
 
+// QuoteIdent returns the name quoted by the dialect.  Sets error if the name is not valid for an identifier.
+func (b *builder) QuoteIdent(name string) string {
+	if !b.CheckIdent(name) {
+		return ""
+	}
+	return b.dialect.QuoteField(name)
+}
+
+// CheckIdent returns true if the name is valid for an identifier.  Sets error if not.
+// Use this for names which are quoted by the dialect.
+func (b *builder) CheckIdent(name string) bool {
+	if strings.IndexByte(name, 0) != -1 {
+		b.SetError(newError("identifier %q contains NUL character.", name))
+		return false
+	}
+	if max := b.dialect.MaxIdentifierLength(); max > 0 && len(name) > max {
+		b.SetError(newError("identifier %q is longer than %d bytes.", name, max))
+		return false
+	}
+	return true
+}
+
 func (b *builder) AppendValue(val interface{}) {
 	if b.err != nil {
 		return
 	}
+	if b.constraint {
+		b.appendLiteral(val)
+		return
+	}
 
-	b.query.WriteString(dialect().BindVar(len(b.args) + 1))
+	b.query.WriteString(b.dialect.BindVar(len(b.args) + 1))
 	b.args = append(b.args, val)
 	return
 }
 
+// appendLiteral writes the val as a literal instead of placeholder.
+func (b *builder) appendLiteral(val interface{}) {
+	if valuer, ok := val.(sqldriver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			b.SetError(err)
+			return
+		}
+		val = v
+	}
+	str, err := b.dialect.LiteralToString(val)
+	if err != nil {
+		b.SetError(err)
+		return
+	}
+	b.query.WriteString(str)
+}
+
 func (b *builder) AppendItems(parts []serializable, sep string) {
 	if parts == nil {
 		return
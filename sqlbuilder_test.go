@@ -4,6 +4,7 @@ import (
 	errs "errors"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,6 +24,211 @@ func TestError(t *testing.T) {
 	}
 }
 
+func TestBuilderDialect(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("test1", nil),
+	)
+
+	numbered := New(NumberedTestDialect{})
+	var cases = []statementTestCase{{
+		stmt:   numbered.Select(table1).Where(table1.C("id").Eq(1)).Limit(10),
+		query:  `SELECT * FROM "TABLE_A" WHERE "TABLE_A"."id"=$1 LIMIT $2;`,
+		args:   []interface{}{int64(1), 10},
+		errmsg: "",
+	}, {
+		stmt:   numbered.Insert(table1).Values(1, 2),
+		query:  `INSERT INTO "TABLE_A" ( "id", "test1" ) VALUES ( $1, $2 );`,
+		args:   []interface{}{int64(1), int64(2)},
+		errmsg: "",
+	}, {
+		stmt:   numbered.Update(table1).Set(table1.C("test1"), 2).Where(table1.C("id").Eq(1)),
+		query:  `UPDATE "TABLE_A" SET "test1"=$1 WHERE "TABLE_A"."id"=$2;`,
+		args:   []interface{}{int64(2), int64(1)},
+		errmsg: "",
+	}, {
+		stmt:   numbered.Delete(table1).Where(table1.C("id").Eq(1)),
+		query:  `DELETE FROM "TABLE_A" WHERE "TABLE_A"."id"=$1;`,
+		args:   []interface{}{int64(1)},
+		errmsg: "",
+	}, {
+		stmt:   numbered.Union(Select(table1).Where(table1.C("id").Eq(1)), Select(table1).Where(table1.C("id").Eq(2))),
+		query:  `SELECT * FROM "TABLE_A" WHERE "TABLE_A"."id"=$1 UNION SELECT * FROM "TABLE_A" WHERE "TABLE_A"."id"=$2;`,
+		args:   []interface{}{int64(1), int64(2)},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Where(table1.C("id").Eq(1)),
+		query:  `SELECT * FROM "TABLE_A" WHERE "TABLE_A"."id"=?;`,
+		args:   []interface{}{int64(1)},
+		errmsg: "",
+	}}
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+
+	query, _, err := Select(table1).Where(table1.C("id").Eq(1)).ToSqlWith(NumberedTestDialect{})
+	if err != nil || query != `SELECT * FROM "TABLE_A" WHERE "TABLE_A"."id"=$1;` {
+		t.Errorf("failed \ngot %s %#v", query, err)
+	}
+	query, _, err = numbered.Select(table1).Where(table1.C("id").Eq(1)).ToSqlWith(TestDialect{})
+	if err != nil || query != `SELECT * FROM "TABLE_A" WHERE "TABLE_A"."id"=?;` {
+		t.Errorf("failed \ngot %s %#v", query, err)
+	}
+}
+
+func TestBuilderDialectConcurrent(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+	)
+
+	builders := []*Builder{New(TestDialect{}), New(NumberedTestDialect{})}
+	expects := []string{
+		`SELECT * FROM "TABLE_A" WHERE "TABLE_A"."id"=?;`,
+		`SELECT * FROM "TABLE_A" WHERE "TABLE_A"."id"=$1;`,
+	}
+	errc := make(chan error, 100)
+	for i := 0; i < 100; i++ {
+		go func(i int) {
+			query, _, err := builders[i%2].Select(table1).Where(table1.C("id").Eq(i)).ToSql()
+			if err == nil && query != expects[i%2] {
+				err = fmt.Errorf("got %s", query)
+			}
+			errc <- err
+		}(i)
+	}
+	for i := 0; i < 100; i++ {
+		if err := <-errc; err != nil {
+			t.Errorf("failed \n%s", err.Error())
+		}
+	}
+}
+
+func TestStatementConcurrent(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		StringColumn("name", nil),
+	)
+	cte := NewCTE("CTE_A", Select(table1).Columns(table1.C("id")))
+
+	// statements are shared by goroutines, run with -race.  expected query is built by another instance.
+	stmts := []func() Statement{
+		func() Statement { return Select(table1).Where(table1.C("id").Eq(1)) },
+		func() Statement { return Insert(table1).Values(1, "foo") },
+		func() Statement { return Update(table1).Set(table1.C("name"), "foo").Where(table1.C("id").Eq(1)) },
+		func() Statement { return Delete(table1).Where(table1.C("id").Eq(1)) },
+		func() Statement {
+			return Union(Select(table1).Columns(table1.C("id")), Select(table1).Columns(table1.C("id"))).OrderBy(false, table1.C("id"))
+		},
+		func() Statement { return With(cte).Select(cte) },
+		func() Statement { return CreateTable(table1).IfNotExists() },
+		func() Statement { return CreateIndex(table1).Name("I_NAME").Columns(table1.C("name")) },
+		func() Statement { return DropTable(table1) },
+		func() Statement { return DropIndex(table1, "I_NAME") },
+		func() Statement { return AlterTable(table1).AddColumn(IntColumn("age", nil)) },
+		func() Statement { return Truncate(table1) },
+	}
+	for _, fn := range stmts {
+		expect, _, err := fn().ToSql()
+		if err != nil {
+			t.Errorf("failed \n%s", err.Error())
+			continue
+		}
+		stmt := fn()
+		errc := make(chan error, 8)
+		for i := 0; i < 8; i++ {
+			go func(i int) {
+				var query string
+				var err error
+				if i%2 == 0 {
+					query, _, err = stmt.ToSql()
+				} else {
+					query, _, err = stmt.ToSqlWith(TestDialect{})
+				}
+				if err == nil && query != expect {
+					err = fmt.Errorf("got %s", query)
+				}
+				errc <- err
+			}(i)
+		}
+		for i := 0; i < 8; i++ {
+			if err := <-errc; err != nil {
+				t.Errorf("failed \n%s", err.Error())
+			}
+		}
+	}
+}
+
+func TestQuoteIdent(t *testing.T) {
+	table1 := NewTable(
+		`TABLE"A`,
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("test\x00", nil),
+	)
+	table2 := NewTable(
+		strings.Repeat("a", 64),
+		&TableOption{},
+		IntColumn("id", nil),
+	)
+	table3 := NewTable(
+		"TABLE_C",
+		&TableOption{
+			UniqueKeys: []UniqueKey{{Name: "uq\x00", Columns: []string{"id"}}},
+		},
+		IntColumn("id", nil),
+	)
+
+	var cases = []statementTestCase{{
+		stmt:   Select(table1).Columns(table1.C("id").As(`id"; DROP TABLE "TABLE_A`)),
+		query:  `SELECT "TABLE""A"."id" AS "id""; DROP TABLE ""TABLE_A" FROM "TABLE""A";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   Select(Select(table1).Columns(table1.C("id")).ToSubquery(`S"Q`)),
+		query:  `SELECT * FROM ( SELECT "TABLE""A"."id" FROM "TABLE""A" ) AS "S""Q";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Columns(table1.C("test\x00")),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: `sqlbuilder: identifier "test\x00" contains NUL character.`,
+	}, {
+		stmt:   Select(table2).Columns(table2.C("id")),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: `sqlbuilder: identifier "` + strings.Repeat("a", 64) + `" is longer than 63 bytes.`,
+	}, {
+		stmt:   CreateTable(table3),
+		query:  "",
+		args:   []interface{}{},
+		errmsg: `sqlbuilder: identifier "uq\x00" contains NUL character.`,
+	}}
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
+
 func ExampleScenario() {
 	db, err := sql.Open("sqlite3", ":memory:")
 	if err != nil {
@@ -101,6 +307,36 @@ func (m TestDialect) BindVar(i int) string {
 	return "?"
 }
 
+func (m TestDialect) MaxPlaceholders() int {
+	return 999
+}
+
+func (m TestDialect) SupportsReturning() bool {
+	return true
+}
+
+func (m TestDialect) ConcatOperator() string {
+	return "||"
+}
+
+// NumberedTestDialect is TestDialect with numbered placeholder like PostgreSQL.
+type NumberedTestDialect struct {
+	TestDialect
+}
+
+func (m NumberedTestDialect) BindVar(i int) string {
+	return fmt.Sprintf("$%d", i)
+}
+
+// NoReturningTestDialect is TestDialect without RETURNING clause like MySQL.
+type NoReturningTestDialect struct {
+	TestDialect
+}
+
+func (m NoReturningTestDialect) SupportsReturning() bool {
+	return false
+}
+
 func (m TestDialect) QuoteField(field interface{}) string {
 	str := ""
 	bracket := true
@@ -127,7 +363,7 @@ func (m TestDialect) QuoteField(field interface{}) string {
 		bracket = false
 	}
 	if bracket {
-		str = "\"" + str + "\""
+		str = "\"" + strings.Replace(str, "\"", "\"\"", -1) + "\""
 	}
 	return str
 }
@@ -188,42 +424,160 @@ func (m TestDialect) ColumnOptionToString(co *ColumnOption) (string, error) {
 }
 
 func (m TestDialect) TableOptionToString(to *TableOption) (string, error) {
-	opt := ""
-	apnd := func(str, opt string) string {
-		if len(str) != 0 {
-			str += " "
+	columns := func(names []string) string {
+		str := "("
+		for i, name := range names {
+			if i != 0 {
+				str += ", "
+			}
+			str += m.QuoteField(name)
 		}
-		str += opt
-		return str
+		return str + ")"
+	}
+	constraints := make([]string, 0)
+	if len(to.PrimaryKey) != 0 {
+		constraints = append(constraints, "PRIMARY KEY"+columns(to.PrimaryKey))
+	}
+	for _, unique := range to.Unique {
+		constraints = append(constraints, "UNIQUE"+columns(unique))
+	}
+	for _, unique := range to.UniqueKeys {
+		str := ""
+		if len(unique.Name) != 0 {
+			str = "CONSTRAINT " + m.QuoteField(unique.Name) + " "
+		}
+		constraints = append(constraints, str+"UNIQUE"+columns(unique.Columns))
+	}
+	return strings.Join(constraints, ", "), nil
+}
+
+func (m TestDialect) LockOptionToString(lo *LockOption) (string, error) {
+	if lo.NoWait && lo.SkipLocked {
+		return "", errs.New("dialects: NOWAIT and SKIP LOCKED can not be used together")
 	}
 
-	if to.Unique != nil {
-		opt = apnd(opt, m.tableOptionUnique(to.Unique))
+	opt := lo.Mode.String()
+	if len(opt) == 0 {
+		return "", errs.New("dialects: unknown lock mode")
+	}
+	if len(lo.Of) != 0 {
+		opt += " OF "
+		for i, name := range lo.Of {
+			if i != 0 {
+				opt += ", "
+			}
+			opt += m.QuoteField(name)
+		}
+	}
+	if lo.NoWait {
+		opt += " NOWAIT"
+	}
+	if lo.SkipLocked {
+		opt += " SKIP LOCKED"
 	}
 	return opt, nil
 }
 
-func (m TestDialect) tableOptionUnique(op [][]string) string {
+func (m TestDialect) UpsertSyntax(uo *UpsertOption) (UpsertSyntax, error) {
+	if uo.Replace {
+		return UpsertInsertOrReplace, nil
+	}
+	if uo.DoUpdate && len(uo.Target) == 0 {
+		return UpsertOnConflict, errs.New("dialects: ON CONFLICT DO UPDATE needs conflict target")
+	}
+	return UpsertOnConflict, nil
+}
+
+func (m TestDialect) CastTypeToString(typ ColumnType) (string, error) {
+	switch typ {
+	case ColumnTypeInt:
+		return "INTEGER", nil
+	case ColumnTypeString:
+		return "TEXT", nil
+	case ColumnTypeDate:
+		return "DATE", nil
+	case ColumnTypeFloat:
+		return "REAL", nil
+	case ColumnTypeBool:
+		return "BOOLEAN", nil
+	case ColumnTypeBytes:
+		return "BLOB", nil
+	}
+	return "", errs.New("dialects: unknown column type")
+}
+
+func (m TestDialect) PagingSyntax(po *PagingOption) (PagingSyntax, error) {
+	return PagingLimitOffset, nil
+}
+
+func (m TestDialect) UpdateCapabilities() UpdateCapability {
+	return UpdateOrderBy | UpdateLimit | UpdateOffset
+}
+
+func (m TestDialect) MaxIdentifierLength() int {
+	return 63
+}
+
+func (m TestDialect) ForeignKeyActionToString(fk *ForeignKey) (string, error) {
 	opt := ""
-	first_op := true
-	for _, unique := range op {
-		if first_op {
-			first_op = false
-		} else {
+	if fk.OnDelete != ForeignKeyNoAction {
+		opt += "ON DELETE " + fk.OnDelete.String()
+	}
+	if fk.OnUpdate != ForeignKeyNoAction {
+		if len(opt) != 0 {
 			opt += " "
 		}
+		opt += "ON UPDATE " + fk.OnUpdate.String()
+	}
+	return opt, nil
+}
 
-		opt += "UNIQUE("
-		first := true
-		for _, col := range unique {
-			if first {
-				first = false
-			} else {
-				opt += ", "
-			}
-			opt += m.QuoteField(col)
+func (m TestDialect) IndexCapabilities() IndexCapability {
+	return IndexPartial | IndexUsing | IndexExpression | IndexDropIfExists
+}
+
+func (m TestDialect) DropTableOptionToString(o *DropTableOption) (string, error) {
+	if o.Cascade {
+		return "CASCADE", nil
+	}
+	return "", nil
+}
+
+func (m TestDialect) TruncateSyntax(o *TruncateOption) (TruncateSyntax, error) {
+	return TruncateTable, nil
+}
+
+func (m TestDialect) LengthFunction() string {
+	return "LENGTH"
+}
+
+func (m TestDialect) QueryCapabilities() QueryCapability {
+	return QueryRecursiveKeyword | QueryTableAliasAs | QueryExceptOperator | QueryModOperator
+}
+
+func (m TestDialect) AlterTableCapabilities() AlterTableCapability {
+	return AlterTableMultipleActions | AlterTableCombineRename | AlterTableColumnKeyword |
+		AlterTableChangeColumn | AlterTableColumnPosition | AlterTableRenameColumn |
+		AlterTableRenameTable | AlterTableAddConstraint
+}
+
+func (m TestDialect) LiteralToString(val interface{}) (string, error) {
+	switch v := val.(type) {
+	case int64:
+		return fmt.Sprint(v), nil
+	case float64:
+		return fmt.Sprint(v), nil
+	case bool:
+		if v {
+			return "TRUE", nil
 		}
-		opt += ")"
+		return "FALSE", nil
+	case string:
+		return "'" + strings.Replace(v, "'", "''", -1) + "'", nil
+	case time.Time:
+		return "'" + v.Format("2006-01-02 15:04:05") + "'", nil
+	case nil:
+		return "NULL", nil
 	}
-	return opt
+	return "", errs.New("dialects: unknown literal type")
 }
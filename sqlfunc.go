@@ -4,12 +4,18 @@ package sqlbuilder
 type SqlFunc interface {
 	Column
 
+	// Over returns the function with "OVER" clause defined by the window.  This can be use in the same way as Column.
+	Over(window *Window) Column
+
+	// Distinct returns a copy of the function with "DISTINCT" in arguments(ex: COUNT(DISTINCT col)).
+	Distinct() SqlFunc
+
 	columns() []Column
 }
 
-type sqlFuncColumnList []Column
+type sqlFuncArgList []serializable
 
-func (l sqlFuncColumnList) serialize(bldr *builder) {
+func (l sqlFuncArgList) serialize(bldr *builder) {
 	first := true
 	for _, part := range l {
 		if first {
@@ -17,21 +23,146 @@ func (l sqlFuncColumnList) serialize(bldr *builder) {
 		} else {
 			bldr.Append(" , ")
 		}
-		part.serialize(bldr)
+		bldr.AppendItem(part)
 	}
 }
 
 type sqlFuncImpl struct {
-	name string
-	args sqlFuncColumnList
+	name     string
+	args     sqlFuncArgList
+	ctyp     ColumnType
+	distinct bool
+	cast     bool
+	keyword  bool
+	length   bool
+	err      error
 }
 
-// Func returns new SQL function.  The name is function name, and the args is arguments of function
+// Func returns new SQL function.  The name is function name, and the args is arguments of function.
+// Give Star for "*"(ex: COUNT(*)).  Use FuncOf() for literal arguments.
+// Type of result is unknown, use typed functions(ex: Count()) to validate it.
 func Func(name string, args ...Column) SqlFunc {
-	return &sqlFuncImpl{
+	l := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		l = append(l, arg)
+	}
+	return newTypedFunc(name, ColumnTypeAny, l...)
+}
+
+// FuncOf returns new SQL function same as Func(), but type for args is Column, SqlFunc or literal value.
+// Literal values are bound as placeholders.
+func FuncOf(name string, args ...interface{}) SqlFunc {
+	return newTypedFunc(name, ColumnTypeAny, args...)
+}
+
+func newTypedFunc(name string, ctyp ColumnType, args ...interface{}) *sqlFuncImpl {
+	m := &sqlFuncImpl{
 		name: name,
-		args: args,
+		args: make(sqlFuncArgList, 0, len(args)),
+		ctyp: ctyp,
+	}
+	for _, arg := range args {
+		m.args = append(m.args, toOperand(arg))
+	}
+	return m
+}
+
+// Count returns "COUNT(column)" function.  Give Star for "COUNT(*)".
+func Count(column Column) SqlFunc {
+	return newTypedFunc("COUNT", ColumnTypeInt, column)
+}
+
+// CountDistinct returns "COUNT(DISTINCT column)" function.
+func CountDistinct(column Column) SqlFunc {
+	return newTypedFunc("COUNT", ColumnTypeInt, column).Distinct()
+}
+
+// Sum returns "SUM(column)" function.
+func Sum(column Column) SqlFunc {
+	typ := argType(column)
+	if typ != ColumnTypeInt && typ != ColumnTypeFloat {
+		typ = ColumnTypeAny
+	}
+	return newTypedFunc("SUM", typ, column)
+}
+
+// Avg returns "AVG(column)" function.
+func Avg(column Column) SqlFunc {
+	return newTypedFunc("AVG", ColumnTypeFloat, column)
+}
+
+// Min returns "MIN(column)" function.
+func Min(column Column) SqlFunc {
+	return newTypedFunc("MIN", argType(column), column)
+}
+
+// Max returns "MAX(column)" function.
+func Max(column Column) SqlFunc {
+	return newTypedFunc("MAX", argType(column), column)
+}
+
+// Coalesce returns "COALESCE(args[0], args[1] ...)" function.  Type for args is Column, SqlFunc or literal value.
+func Coalesce(args ...interface{}) SqlFunc {
+	m := newTypedFunc("COALESCE", ColumnTypeAny, args...)
+	for _, arg := range m.args {
+		if t := operandType(arg); t != ColumnTypeAny {
+			m.ctyp = t
+			break
+		}
+	}
+	return m
+}
+
+// Lower returns "LOWER(column)" function.
+func Lower(column Column) SqlFunc {
+	return newTypedFunc("LOWER", ColumnTypeString, column)
+}
+
+// Upper returns "UPPER(column)" function.
+func Upper(column Column) SqlFunc {
+	return newTypedFunc("UPPER", ColumnTypeString, column)
+}
+
+// Length returns "LENGTH(column)" function.  The function name is given by the dialect.
+func Length(column Column) SqlFunc {
+	m := newTypedFunc("LENGTH", ColumnTypeInt, column)
+	m.length = true
+	return m
+}
+
+// Abs returns "ABS(column)" function.
+func Abs(column Column) SqlFunc {
+	return newTypedFunc("ABS", argType(column), column)
+}
+
+// Round returns "ROUND(column)" function.
+func Round(column Column) SqlFunc {
+	return newTypedFunc("ROUND", argType(column), column)
+}
+
+// Now returns "CURRENT_TIMESTAMP" for current date and time.
+func Now() SqlFunc {
+	m := newTypedFunc("CURRENT_TIMESTAMP", ColumnTypeDate)
+	m.keyword = true
+	return m
+}
+
+// Cast returns "CAST(column AS type)" function.  The type name is given by the dialect.
+func Cast(column Column, typ ColumnType) SqlFunc {
+	m := newTypedFunc("CAST", typ, column)
+	m.cast = true
+	return m
+}
+
+// argType returns ColumnType of the column.  returns ColumnTypeAny if it is unknown.
+func argType(column Column) ColumnType {
+	if column == nil {
+		return ColumnTypeAny
+	}
+	if cc := column.config(); cc != nil {
+		return cc.Type()
 	}
+	return ColumnTypeAny
 }
 
 func (m *sqlFuncImpl) As(alias string) Column {
@@ -41,8 +172,9 @@ func (m *sqlFuncImpl) As(alias string) Column {
 	}
 }
 
+// column_name returns empty.  Databases name the result of function differently, so use As() to refer it.
 func (m *sqlFuncImpl) column_name() string {
-	return m.name
+	return ""
 }
 
 func (m *sqlFuncImpl) not_null() bool {
@@ -50,17 +182,58 @@ func (m *sqlFuncImpl) not_null() bool {
 }
 
 func (m *sqlFuncImpl) config() ColumnConfig {
-	return nil
+	return newColumnConfigImpl("", m.ctyp, nil)
 }
 
-func (m *sqlFuncImpl) acceptType(interface{}) bool {
-	return false
+func (m *sqlFuncImpl) acceptType(val interface{}) bool {
+	return acceptLiteralType(m.ctyp, false, val)
+}
+
+func (m *sqlFuncImpl) Over(window *Window) Column {
+	return &windowFunc{
+		fn:     m,
+		window: window,
+	}
+}
+
+func (m *sqlFuncImpl) Distinct() SqlFunc {
+	c := *m
+	c.distinct = true
+	for _, arg := range c.args {
+		if arg == Star {
+			c.err = newError("DISTINCT can not be used with *.")
+		}
+	}
+	return &c
 }
 
 func (m *sqlFuncImpl) serialize(bldr *builder) {
-	bldr.Append(m.name)
+	if m.err != nil {
+		bldr.SetError(m.err)
+		return
+	}
+	if m.keyword {
+		bldr.Append(m.name)
+		return
+	}
+	if m.length {
+		bldr.Append(bldr.dialect.LengthFunction())
+	} else {
+		bldr.Append(m.name)
+	}
 	bldr.Append("(")
+	if m.distinct {
+		bldr.Append("DISTINCT ")
+	}
 	bldr.AppendItem(m.args)
+	if m.cast {
+		typ, err := bldr.dialect.CastTypeToString(m.ctyp)
+		if err != nil {
+			bldr.SetError(err)
+			return
+		}
+		bldr.Append(" AS " + typ)
+	}
 	bldr.Append(")")
 }
 
@@ -92,14 +265,46 @@ func (left *sqlFuncImpl) Like(right string) Condition {
 	return newBinaryOperationCondition(left, right, " LIKE ")
 }
 
+func (left *sqlFuncImpl) NotLike(right string) Condition {
+	return newBinaryOperationCondition(left, right, " NOT LIKE ")
+}
+
 func (left *sqlFuncImpl) Between(lower, higher interface{}) Condition {
 	return newBetweenCondition(left, lower, higher)
 }
 
+func (left *sqlFuncImpl) NotBetween(lower, higher interface{}) Condition {
+	return newNotBetweenCondition(left, lower, higher)
+}
+
 func (left *sqlFuncImpl) In(vals ...interface{}) Condition {
 	return newInCondition(left, vals...)
 }
 
+func (left *sqlFuncImpl) NotIn(vals ...interface{}) Condition {
+	return newNotInCondition(left, vals...)
+}
+
+func (left *sqlFuncImpl) IsNull() Condition {
+	return newNullCondition(left, false)
+}
+
+func (left *sqlFuncImpl) IsNotNull() Condition {
+	return newNullCondition(left, true)
+}
+
+// columns returns columns referred in arguments.  Literals and "*" are not contained.
 func (m *sqlFuncImpl) columns() []Column {
-	return m.args
+	list := make([]Column, 0, len(m.args))
+	for _, arg := range m.args {
+		switch t := arg.(type) {
+		case compositeColumn:
+			list = append(list, t.columns()...)
+		case Column:
+			if t != Star {
+				list = append(list, t)
+			}
+		}
+	}
+	return list
 }
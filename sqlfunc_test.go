@@ -35,3 +35,201 @@ func TestSqlFunc(t *testing.T) {
 		t.Errorf("failed")
 	}
 }
+
+func TestTypedSqlFunc(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		StringColumn("name", nil),
+		FloatColumn("score", nil),
+		DateColumn("created", nil),
+	)
+
+	var cases = []statementTestCase{{
+		stmt: Select(table1).Columns(
+			Count(Star), CountDistinct(table1.C("name")), Sum(table1.C("id")), Avg(table1.C("score")),
+			Min(table1.C("id")), Max(table1.C("created")),
+		),
+		query:  `SELECT COUNT(*), COUNT(DISTINCT "TABLE_A"."name"), SUM("TABLE_A"."id"), AVG("TABLE_A"."score"), MIN("TABLE_A"."id"), MAX("TABLE_A"."created") FROM "TABLE_A";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt: Select(table1).Columns(
+			Coalesce(table1.C("name"), Upper(table1.C("name"))), Lower(table1.C("name")), Length(table1.C("name")),
+			Abs(table1.C("score")), Round(table1.C("score")), Now(), Cast(table1.C("id"), ColumnTypeString),
+		),
+		query:  `SELECT COALESCE("TABLE_A"."name" , UPPER("TABLE_A"."name")), LOWER("TABLE_A"."name"), LENGTH("TABLE_A"."name"), ABS("TABLE_A"."score"), ROUND("TABLE_A"."score"), CURRENT_TIMESTAMP, CAST("TABLE_A"."id" AS TEXT) FROM "TABLE_A";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Columns(table1.C("name")).GroupBy(table1.C("name")).Having(Count(table1.C("id")).Gt(5)),
+		query:  `SELECT "TABLE_A"."name" FROM "TABLE_A" GROUP BY "TABLE_A"."name" HAVING COUNT("TABLE_A"."id")>?;`,
+		args:   []interface{}{int64(5)},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Where(Avg(table1.C("score")).Between(1, 2.5)),
+		query:  `SELECT * FROM "TABLE_A" WHERE AVG("TABLE_A"."score") BETWEEN ? AND ?;`,
+		args:   []interface{}{int64(1), float64(2.5)},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Where(table1.C("created").Lt(Now())),
+		query:  `SELECT * FROM "TABLE_A" WHERE "TABLE_A"."created"<CURRENT_TIMESTAMP;`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Columns(table1.C("name")).GroupBy(table1.C("name")).Having(Count(table1.C("id")).Gt("five")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: int column not accept string.",
+	}, {
+		stmt:   Select(table1).Where(Lower(table1.C("name")).In("a", 1)),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: string column not accept int.",
+	}, {
+		stmt:   Select(table1).Where(Length(table1.C("name")).Eq(table1.C("created"))),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: int column not accept date column.",
+	}, {
+		// table columns are not checked.  databases convert the value implicitly.
+		stmt:   Select(table1).Where(table1.C("id").Eq("1")),
+		query:  `SELECT * FROM "TABLE_A" WHERE "TABLE_A"."id"=?;`,
+		args:   []interface{}{"1"},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Columns(Cast(table1.C("id"), ColumnTypeAny)),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "dialects: unknown column type",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
+
+func TestTypedSqlFuncInSubquery(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		StringColumn("name", nil),
+	)
+
+	sq := Select(table1).
+		Columns(table1.C("name"), Count(table1.C("id")).As("cnt")).
+		GroupBy(table1.C("name")).
+		ToSubquery("SQ1")
+	query, args, err := Select(sq).Columns(sq.C("name")).Where(sq.C("cnt").Gt(1)).ToSql()
+	if err != nil {
+		t.Errorf("failed \ngot %s", err.Error())
+	}
+	if query != `SELECT "SQ1"."name" FROM ( SELECT "TABLE_A"."name", COUNT("TABLE_A"."id") AS "cnt" FROM "TABLE_A" GROUP BY "TABLE_A"."name" ) AS "SQ1" WHERE "SQ1"."cnt">?;` {
+		t.Errorf("failed \ngot %s", query)
+	}
+	if !reflect.DeepEqual(args, []interface{}{int64(1)}) {
+		t.Errorf("failed \ngot %#v", args)
+	}
+
+	// columns of subquery are not checked in the same way as table columns.
+	_, _, err = Select(sq).Where(sq.C("cnt").Gt("1")).ToSql()
+	if err != nil {
+		t.Errorf("failed \ngot %#v", err)
+	}
+
+	// databases name unaliased function differently, so it can not be referred.
+	sq2 := Select(table1).Columns(Count(table1.C("id"))).ToSubquery("SQ2")
+	_, _, err = Select(sq2).Columns(sq2.C("COUNT")).ToSql()
+	if err == nil || err.Error() != "sqlbuilder: column not found in FROM." {
+		t.Errorf("failed \ngot %#v", err)
+	}
+}
+
+func TestSqlFuncArgs(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		StringColumn("name", nil),
+		DateColumn("created", nil),
+	)
+	table2 := NewTable(
+		"TABLE_B",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+	)
+	count := Count(table1.C("name"))
+
+	var cases = []statementTestCase{{
+		stmt:   Select(table1).Columns(Coalesce(table1.C("name"), "unknown").As("name")),
+		query:  `SELECT COALESCE("TABLE_A"."name" , ?) AS "name" FROM "TABLE_A";`,
+		args:   []interface{}{"unknown"},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Columns(FuncOf("SUBSTR", table1.C("name"), 1, 3)).Where(table1.C("id").Eq(10)),
+		query:  `SELECT SUBSTR("TABLE_A"."name" , ? , ?) FROM "TABLE_A" WHERE "TABLE_A"."id"=?;`,
+		args:   []interface{}{int64(1), int64(3), int64(10)},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Columns(FuncOf("DATE_TRUNC", "day", table1.C("created"))),
+		query:  `SELECT DATE_TRUNC(? , "TABLE_A"."created") FROM "TABLE_A";`,
+		args:   []interface{}{"day"},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Columns(Func("LENGTH", Func("TRIM", table1.C("name")))),
+		query:  `SELECT LENGTH(TRIM("TABLE_A"."name")) FROM "TABLE_A";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Columns(Func("COUNT", Star), Func("SUM", table1.C("id")).Distinct()),
+		query:  `SELECT COUNT(*), SUM(DISTINCT "TABLE_A"."id") FROM "TABLE_A";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Columns(Func("LENGTH", Func("TRIM", table2.C("id")))),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column not found in FROM.",
+	}, {
+		stmt:   Select(table1).Columns(Count(Star).Distinct()),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: DISTINCT can not be used with *.",
+	}, {
+		stmt:   Select(table1).Columns(CountDistinct(Star)),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: DISTINCT can not be used with *.",
+	}, {
+		// Distinct() does not change the original function.
+		stmt:   Select(table1).Columns(count, count.Distinct()),
+		query:  `SELECT COUNT("TABLE_A"."name"), COUNT(DISTINCT "TABLE_A"."name") FROM "TABLE_A";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+
+	cols := FuncOf("SUBSTR", Func("TRIM", table1.C("name")), 1, Star).columns()
+	if len(cols) != 1 || cols[0] != table1.C("name") {
+		t.Errorf("failed \ngot %#v", cols)
+	}
+}
@@ -17,8 +17,14 @@ type table struct {
 
 // TableOption reprecents constraint of a table.
 type TableOption struct {
-	Unique [][]string
-	//ForeignKey map[string]Column // will implement future
+	// Schema qualifies the table name like "schema"."table".  On MySQL, set the database name.
+	Schema string
+
+	PrimaryKey  []string
+	Unique      [][]string
+	UniqueKeys  []UniqueKey
+	Checks      []Check
+	ForeignKeys []ForeignKey
 }
 
 type joinTable struct {
@@ -92,7 +98,7 @@ func NewTable(name string, option *TableOption, column_configs ...ColumnConfig)
 }
 
 func (m *table) serialize(bldr *builder) {
-	bldr.Append(dialect().QuoteField(m.name))
+	bldr.Append(qualifiedTableName(bldr, m))
 	return
 }
 
@@ -296,22 +302,11 @@ func (m *table) hasColumn(trg Column) bool {
 		return false
 	}
 	if acol, ok := trg.(*aliasColumn); ok {
-		for _, col := range m.columns {
-			if col == acol.column {
-				return true
-			}
-		}
-		return false
+		return m.hasColumn(acol.column)
 	}
-	if sqlfn, ok := trg.(*sqlFuncImpl); ok {
-		for _, fncol := range sqlfn.columns() {
-			find := false
-			for _, col := range m.columns {
-				if col == fncol {
-					find = true
-				}
-			}
-			if !find {
+	if ccol, ok := trg.(compositeColumn); ok {
+		for _, col := range ccol.columns() {
+			if !m.hasColumn(col) {
 				return false
 			}
 		}
@@ -412,5 +407,36 @@ func (m *joinTable) hasColumn(trg Column) bool {
 	if m.right.hasColumn(trg) {
 		return true
 	}
+	if acol, ok := trg.(*aliasColumn); ok {
+		return m.hasColumn(acol.column)
+	}
+	if ccol, ok := trg.(compositeColumn); ok {
+		for _, col := range ccol.columns() {
+			if !m.hasColumn(col) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// qualifiedTableName returns quoted name of the tbl, qualified by its schema if it is set.
+func qualifiedTableName(bldr *builder, tbl Table) string {
+	name := bldr.QuoteIdent(tbl.Name())
+	if opt := tbl.Option(); opt != nil && len(opt.Schema) != 0 {
+		name = bldr.QuoteIdent(opt.Schema) + "." + name
+	}
+	return name
+}
+
+// containsTable returns true if the trg is the from or is joined in the from.
+func containsTable(from, trg Table) bool {
+	if from == trg {
+		return true
+	}
+	if jt, ok := from.(*joinTable); ok {
+		return containsTable(jt.left, trg) || containsTable(jt.right, trg)
+	}
 	return false
 }
@@ -303,3 +303,75 @@ func TestTableColumnOperation(t *testing.T) {
 	}
 	return
 }
+
+func TestSchemaTable(t *testing.T) {
+	events := NewTable(
+		"events",
+		&TableOption{
+			Schema: "analytics",
+		},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("value", nil),
+	)
+	publicEvents := NewTable(
+		"events",
+		&TableOption{
+			Schema: "public",
+		},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+	)
+
+	var cases = []statementTestCase{{
+		stmt: Select(events.InnerJoin(publicEvents, events.C("id").Eq(publicEvents.C("id")))).
+			Columns(events.C("value")).
+			Where(publicEvents.C("id").Eq(1)),
+		query:  `SELECT "analytics"."events"."value" FROM "analytics"."events" INNER JOIN "public"."events" ON "analytics"."events"."id"="public"."events"."id" WHERE "public"."events"."id"=?;`,
+		args:   []interface{}{int64(1)},
+		errmsg: "",
+	}, {
+		stmt:   Insert(events).Values(1, 2),
+		query:  `INSERT INTO "analytics"."events" ( "id", "value" ) VALUES ( ?, ? );`,
+		args:   []interface{}{int64(1), int64(2)},
+		errmsg: "",
+	}, {
+		stmt:   Update(events).Set(events.C("value"), 2).Where(events.C("id").Eq(1)),
+		query:  `UPDATE "analytics"."events" SET "value"=? WHERE "analytics"."events"."id"=?;`,
+		args:   []interface{}{int64(2), int64(1)},
+		errmsg: "",
+	}, {
+		stmt:   Delete(events).Where(events.C("id").Eq(1)),
+		query:  `DELETE FROM "analytics"."events" WHERE "analytics"."events"."id"=?;`,
+		args:   []interface{}{int64(1)},
+		errmsg: "",
+	}, {
+		stmt:   CreateTable(events),
+		query:  `CREATE TABLE "analytics"."events" ( "id" INTEGER PRIMARY KEY, "value" INTEGER );`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   CreateIndex(events).Name("I_VALUE").Columns(events.C("value")),
+		query:  `CREATE INDEX "I_VALUE" ON "analytics"."events" ( "value" );`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   DropTable(events),
+		query:  `DROP TABLE "analytics"."events";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   AlterTable(events).AddColumn(IntColumn("test", nil)),
+		query:  `ALTER TABLE "analytics"."events" ADD COLUMN "test" INTEGER;`,
+		args:   []interface{}{},
+		errmsg: "",
+	}}
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
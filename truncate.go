@@ -0,0 +1,127 @@
+package sqlbuilder
+
+// TruncateSyntax represents a syntax of truncate which a dialect uses.
+type TruncateSyntax int
+
+const (
+	// TRUNCATE TABLE ... [RESTART IDENTITY] [CASCADE]
+	TruncateTable TruncateSyntax = iota
+	// DELETE FROM ...
+	TruncateDeleteFrom
+)
+
+// TruncateOption represents a form of truncate.
+// Dialects handle this for choose its syntax, or return an error for unsupported option.
+type TruncateOption struct {
+	TableCount      int
+	RestartIdentity bool
+	Cascade         bool
+}
+
+// TruncateStatement represents a "TRUNCATE TABLE" statement.
+type TruncateStatement struct {
+	tables          []Table
+	restartIdentity bool
+	cascade         bool
+
+	dialect Dialect
+
+	err error
+}
+
+// Truncate returns new "TRUNCATE TABLE" statement. The tbls are Table objects to truncate.
+func Truncate(tbls ...Table) *TruncateStatement {
+	if len(tbls) == 0 {
+		return &TruncateStatement{
+			err: newError("table is nil."),
+		}
+	}
+	for _, tbl := range tbls {
+		if tbl == nil {
+			return &TruncateStatement{
+				err: newError("table is nil."),
+			}
+		}
+		if _, ok := tbl.(*table); !ok {
+			return &TruncateStatement{
+				err: newError("table is not natural table."),
+			}
+		}
+	}
+	return &TruncateStatement{
+		tables: tbls,
+	}
+}
+
+// RestartIdentity sets "RESTART IDENTITY" option, resets sequences owned by columns of the tables.
+func (b *TruncateStatement) RestartIdentity() *TruncateStatement {
+	if b.err != nil {
+		return b
+	}
+	b.restartIdentity = true
+	return b
+}
+
+// Cascade sets "CASCADE" option, truncates tables which have foreign keys to the tables.
+func (b *TruncateStatement) Cascade() *TruncateStatement {
+	if b.err != nil {
+		return b
+	}
+	b.cascade = true
+	return b
+}
+
+// ToSql generates query string, placeholder arguments, and returns err on errors.
+func (b *TruncateStatement) ToSql() (query string, args []interface{}, err error) {
+	return b.ToSqlWith(b.dialect)
+}
+
+// ToSqlWith generates query string, placeholder arguments with the d, and returns err on errors.
+// The default dialect is used if the d is nil.
+func (b *TruncateStatement) ToSqlWith(d Dialect) (query string, args []interface{}, err error) {
+	bldr := newBuilderWith(d)
+	defer func() {
+		query, args, err = bldr.Query(), bldr.Args(), bldr.Err()
+	}()
+	if b.err != nil {
+		bldr.SetError(b.err)
+		return
+	}
+
+	syntax, e := bldr.dialect.TruncateSyntax(&TruncateOption{
+		TableCount:      len(b.tables),
+		RestartIdentity: b.restartIdentity,
+		Cascade:         b.cascade,
+	})
+	if e != nil {
+		bldr.SetError(e)
+		return
+	}
+
+	switch syntax {
+	case TruncateTable:
+		bldr.Append("TRUNCATE TABLE ")
+	case TruncateDeleteFrom:
+		if len(b.tables) != 1 || b.restartIdentity || b.cascade {
+			bldr.SetError(newError("DELETE FROM can not emulate TRUNCATE with multiple tables or options.(maybe, a bug is in implements of dialect.)"))
+			return
+		}
+		bldr.Append("DELETE FROM ")
+	default:
+		bldr.SetError(newError("unknown truncate syntax.(maybe, a bug is in implements of dialect.)"))
+		return
+	}
+	for i, tbl := range b.tables {
+		if i != 0 {
+			bldr.Append(", ")
+		}
+		bldr.AppendItem(tbl)
+	}
+	if b.restartIdentity {
+		bldr.Append(" RESTART IDENTITY")
+	}
+	if b.cascade {
+		bldr.Append(" CASCADE")
+	}
+	return
+}
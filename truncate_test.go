@@ -0,0 +1,52 @@
+package sqlbuilder
+
+import (
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("test1", nil),
+	)
+	table2 := NewTable(
+		"TABLE_B",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+	)
+	tableJoined := table1.InnerJoin(table2, table1.C("test1").Eq(table2.C("id")))
+
+	var cases = []statementTestCase{{
+		stmt:   Truncate(table1),
+		query:  `TRUNCATE TABLE "TABLE_A";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   Truncate(table1, table2).RestartIdentity().Cascade(),
+		query:  `TRUNCATE TABLE "TABLE_A", "TABLE_B" RESTART IDENTITY CASCADE;`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   Truncate(nil),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: table is nil.",
+	}, {
+		stmt:   Truncate(tableJoined),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: table is not natural table.",
+	}}
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}
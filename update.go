@@ -1,13 +1,28 @@
 package sqlbuilder
 
+// UpdateCapability represents clauses which a dialect accepts in UPDATE statement.
+type UpdateCapability int
+
+const (
+	// UPDATE ... ORDER BY
+	UpdateOrderBy UpdateCapability = 1 << iota
+	// UPDATE ... LIMIT
+	UpdateLimit
+	// UPDATE ... OFFSET
+	UpdateOffset
+)
+
 // UpdateStatement represents a UPDATE statement.
 type UpdateStatement struct {
-	table   Table
-	set     []serializable
-	where   Condition
-	orderBy []serializable
-	limit   int
-	offset  int
+	table     Table
+	set       []serializable
+	where     Condition
+	orderBy   []serializable
+	limit     int
+	offset    int
+	returning returningColumnList
+
+	dialect Dialect
 
 	err error
 }
@@ -34,6 +49,10 @@ func (b *UpdateStatement) Set(col Column, val interface{}) *UpdateStatement {
 		b.err = newError("column not found in FROM.")
 		return b
 	}
+	if vcol, ok := val.(Column); ok && !b.table.hasColumn(vcol) {
+		b.err = newError("column not found in FROM.")
+		return b
+	}
 	b.set = append(b.set, newUpdateValue(col, val))
 	return b
 }
@@ -80,9 +99,30 @@ func (b *UpdateStatement) OrderBy(desc bool, columns ...Column) *UpdateStatement
 	return b
 }
 
+// Returning sets RETURNING clause.  The columns are returned from updated rows.
+func (b *UpdateStatement) Returning(columns ...Column) *UpdateStatement {
+	if b.err != nil {
+		return b
+	}
+	for _, col := range columns {
+		if !b.table.hasColumn(col) {
+			b.err = newError("column not found in FROM.")
+			return b
+		}
+	}
+	b.returning = returningColumnList(columns)
+	return b
+}
+
 // ToSql generates query string, placeholder arguments, and returns err on errors.
 func (b *UpdateStatement) ToSql() (query string, args []interface{}, err error) {
-	bldr := newBuilder()
+	return b.ToSqlWith(b.dialect)
+}
+
+// ToSqlWith generates query string, placeholder arguments with the d, and returns err on errors.
+// The default dialect is used if the d is nil.
+func (b *UpdateStatement) ToSqlWith(d Dialect) (query string, args []interface{}, err error) {
+	bldr := newBuilderWith(d)
 	defer func() {
 		query, args, err = bldr.Query(), bldr.Args(), bldr.Err()
 	}()
@@ -95,6 +135,10 @@ func (b *UpdateStatement) ToSql() (query string, args []interface{}, err error)
 	bldr.Append("UPDATE ")
 	bldr.AppendItem(b.table)
 
+	// columns in subqueries can refer the table.
+	bldr.pushScope(b.table)
+	defer bldr.popScope()
+
 	bldr.Append(" SET ")
 	if len(b.set) != 0 {
 		bldr.AppendItems(b.set, ", ")
@@ -108,32 +152,53 @@ func (b *UpdateStatement) ToSql() (query string, args []interface{}, err error)
 		bldr.AppendItem(b.where)
 	}
 
+	caps := bldr.dialect.UpdateCapabilities()
+
 	// ORDER BY
 	if b.orderBy != nil {
+		if caps&UpdateOrderBy == 0 {
+			bldr.SetError(newError("dialect does not support ORDER BY clause in UPDATE statement."))
+			return
+		}
 		bldr.Append(" ORDER BY ")
 		bldr.AppendItems(b.orderBy, ", ")
 	}
 
-	// LIMIT
-	if b.limit != 0 {
-		bldr.Append(" LIMIT ")
-		bldr.AppendValue(b.limit)
+	// LIMIT, OFFSET
+	if b.limit != 0 && caps&UpdateLimit == 0 {
+		bldr.SetError(newError("dialect does not support LIMIT clause in UPDATE statement."))
+		return
 	}
+	if b.offset != 0 && caps&UpdateOffset == 0 {
+		bldr.SetError(newError("dialect does not support OFFSET clause in UPDATE statement."))
+		return
+	}
+	serializePaging(bldr, &PagingOption{
+		Limit:   b.limit,
+		Offset:  b.offset,
+		OrderBy: b.orderBy != nil,
+	})
 
-	// Offset
-	if b.offset != 0 {
-		bldr.Append(" OFFSET ")
-		bldr.AppendValue(b.offset)
+	// RETURNING
+	if len(b.returning) != 0 {
+		bldr.Append(" RETURNING ")
+		bldr.AppendItem(b.returning)
 	}
 	return
 }
 
 type updateValue struct {
 	col Column
-	val literal
+	val serializable
 }
 
 func newUpdateValue(col Column, val interface{}) updateValue {
+	if vcol, ok := val.(Column); ok {
+		return updateValue{
+			col: col,
+			val: vcol,
+		}
+	}
 	return updateValue{
 		col: col,
 		val: toLiteral(val),
@@ -141,15 +206,26 @@ func newUpdateValue(col Column, val interface{}) updateValue {
 }
 
 func (m updateValue) serialize(bldr *builder) {
-	if !m.col.acceptType(m.val) {
-		bldr.SetError(newError("%s column not accept %T.",
-			m.col.config().Type().String(),
-			m.val.Raw(),
-		))
-		return
+	switch t := m.val.(type) {
+	case Column:
+		if !acceptColumnType(m.col, t) {
+			bldr.SetError(newError("%s column not accept %s column.",
+				m.col.config().Type().String(),
+				t.config().Type().String(),
+			))
+			return
+		}
+	case literal:
+		if !m.col.acceptType(t) {
+			bldr.SetError(newError("%s column not accept %T.",
+				m.col.config().Type().String(),
+				t.Raw(),
+			))
+			return
+		}
 	}
 
-	bldr.Append(dialect().QuoteField(m.col.column_name()))
+	bldr.Append(bldr.QuoteIdent(m.col.column_name()))
 	bldr.Append("=")
 	bldr.AppendItem(m.val)
 }
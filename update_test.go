@@ -40,6 +40,20 @@ func TestUpdate(t *testing.T) {
 		query:  `UPDATE "TABLE_A" SET "test1"=?, "test2"=? WHERE "TABLE_A"."id"=?;`,
 		args:   []interface{}{int64(10), int64(20), int64(1)},
 		errmsg: "",
+	}, {
+		stmt: Update(table1).Where(table1.C("id").Eq(1)).
+			Set(table1.C("test1"), 10).
+			Returning(table1.C("id"), table1.C("test1").As("new_test1")),
+		query:  `UPDATE "TABLE_A" SET "test1"=? WHERE "TABLE_A"."id"=? RETURNING "id", "test1" AS "new_test1";`,
+		args:   []interface{}{int64(10), int64(1)},
+		errmsg: "",
+	}, {
+		stmt: Update(table1).
+			Set(table1.C("test1"), 10).
+			Returning(table2.C("id")),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column not found in FROM.",
 	}, {
 		stmt: Update(nil).Where(table1.C("id").Eq(1)).
 			Set(table1.C("test1"), 10).
@@ -0,0 +1,336 @@
+package sqlbuilder
+
+import (
+	"strconv"
+)
+
+type frameType int
+
+const (
+	rows_frame frameType = iota
+	range_frame
+)
+
+type frameBoundType int
+
+const (
+	unbounded_preceding frameBoundType = iota
+	preceding
+	current_row
+	following
+	unbounded_following
+)
+
+// FrameBound represents a start or end of window frame.
+type FrameBound struct {
+	typ    frameBoundType
+	offset int
+}
+
+var (
+	// UnboundedPreceding is a frame bound for "UNBOUNDED PRECEDING".
+	UnboundedPreceding = FrameBound{typ: unbounded_preceding}
+
+	// CurrentRow is a frame bound for "CURRENT ROW".
+	CurrentRow = FrameBound{typ: current_row}
+
+	// UnboundedFollowing is a frame bound for "UNBOUNDED FOLLOWING".
+	UnboundedFollowing = FrameBound{typ: unbounded_following}
+)
+
+// Preceding returns a frame bound for "offset PRECEDING".
+func Preceding(offset int) FrameBound {
+	return FrameBound{typ: preceding, offset: offset}
+}
+
+// Following returns a frame bound for "offset FOLLOWING".
+func Following(offset int) FrameBound {
+	return FrameBound{typ: following, offset: offset}
+}
+
+func (m FrameBound) serialize(bldr *builder) {
+	switch m.typ {
+	case unbounded_preceding:
+		bldr.Append("UNBOUNDED PRECEDING")
+	case preceding:
+		bldr.Append(strconv.Itoa(m.offset) + " PRECEDING")
+	case current_row:
+		bldr.Append("CURRENT ROW")
+	case following:
+		bldr.Append(strconv.Itoa(m.offset) + " FOLLOWING")
+	case unbounded_following:
+		bldr.Append("UNBOUNDED FOLLOWING")
+	}
+}
+
+type windowFrame struct {
+	typ   frameType
+	start FrameBound
+	end   FrameBound
+}
+
+func (m *windowFrame) serialize(bldr *builder) {
+	switch m.typ {
+	case rows_frame:
+		bldr.Append("ROWS BETWEEN ")
+	case range_frame:
+		bldr.Append("RANGE BETWEEN ")
+	}
+	bldr.AppendItem(m.start)
+	bldr.Append(" AND ")
+	bldr.AppendItem(m.end)
+}
+
+// Window represents a window definition for OVER clause and WINDOW clause.
+type Window struct {
+	name        string
+	partitionBy []Column
+	orderBy     []serializable
+	frame       *windowFrame
+
+	err error
+}
+
+// NewWindow returns new window definition which is written in OVER clause directly.
+func NewWindow() *Window {
+	return &Window{}
+}
+
+// NewNamedWindow returns new window definition named by the name.
+// Define it with SelectStatement.Window(), then OVER clause refers it by the name.
+func NewNamedWindow(name string) *Window {
+	m := &Window{
+		name: name,
+	}
+	if len(name) == 0 {
+		m.err = newError("name of window is empty.")
+	}
+	return m
+}
+
+// PartitionBy sets "PARTITION BY" clause by the columns.
+func (m *Window) PartitionBy(columns ...Column) *Window {
+	if m.err != nil {
+		return m
+	}
+	m.partitionBy = columns
+	return m
+}
+
+// OrderBy sets "ORDER BY" clause. Use descending order if the desc is true, by the columns.
+func (m *Window) OrderBy(desc bool, columns ...Column) *Window {
+	if m.err != nil {
+		return m
+	}
+	if m.orderBy == nil {
+		m.orderBy = make([]serializable, 0)
+	}
+
+	for _, c := range columns {
+		m.orderBy = append(m.orderBy, newOrderBy(desc, c))
+	}
+	return m
+}
+
+// Rows sets "ROWS BETWEEN start AND end" frame clause.
+func (m *Window) Rows(start, end FrameBound) *Window {
+	return m.setFrame(rows_frame, start, end)
+}
+
+// Range sets "RANGE BETWEEN start AND end" frame clause.
+func (m *Window) Range(start, end FrameBound) *Window {
+	return m.setFrame(range_frame, start, end)
+}
+
+func (m *Window) setFrame(typ frameType, start, end FrameBound) *Window {
+	if m.err != nil {
+		return m
+	}
+	if start.typ == unbounded_following || end.typ == unbounded_preceding || start.typ > end.typ {
+		m.err = newError("invalid window frame.")
+		return m
+	}
+	if start.offset < 0 || end.offset < 0 {
+		m.err = newError("offset of window frame must be zero or more.")
+		return m
+	}
+	m.frame = &windowFrame{
+		typ:   typ,
+		start: start,
+		end:   end,
+	}
+	return m
+}
+
+// Name returns window's name.  returns empty if it is not named.
+func (m *Window) Name() string {
+	return m.name
+}
+
+// serializeSpec serializes the window specification without parentheses.
+func (m *Window) serializeSpec(bldr *builder) {
+	if m.err != nil {
+		bldr.SetError(m.err)
+		return
+	}
+
+	first := true
+	if len(m.partitionBy) != 0 {
+		first = false
+		bldr.Append("PARTITION BY ")
+		parts := make([]serializable, len(m.partitionBy))
+		for i := range m.partitionBy {
+			parts[i] = m.partitionBy[i]
+		}
+		bldr.AppendItems(parts, ", ")
+	}
+	if len(m.orderBy) != 0 {
+		if !first {
+			bldr.Append(" ")
+		}
+		first = false
+		bldr.Append("ORDER BY ")
+		bldr.AppendItems(m.orderBy, ", ")
+	}
+	if m.frame != nil {
+		if !first {
+			bldr.Append(" ")
+		}
+		bldr.AppendItem(m.frame)
+	}
+}
+
+// serializeDefinition serializes the window for WINDOW clause.
+func (m *Window) serializeDefinition(bldr *builder) {
+	bldr.Append(bldr.QuoteIdent(m.name) + " AS ( ")
+	m.serializeSpec(bldr)
+	bldr.Append(" )")
+}
+
+func (m *Window) columns() []Column {
+	list := make([]Column, 0, len(m.partitionBy)+len(m.orderBy))
+	list = append(list, m.partitionBy...)
+	for _, o := range m.orderBy {
+		if ob, ok := o.(*orderBy); ok {
+			list = append(list, ob.column)
+		}
+	}
+	return list
+}
+
+// windowFunc is a SQL function with OVER clause.  This can be use in the same way as Column.
+type windowFunc struct {
+	fn     *sqlFuncImpl
+	window *Window
+}
+
+func (m *windowFunc) serialize(bldr *builder) {
+	if m.window == nil {
+		bldr.SetError(newError("window is nil."))
+		return
+	}
+	bldr.AppendItem(m.fn)
+	if len(m.window.name) != 0 {
+		if m.window.err != nil {
+			bldr.SetError(m.window.err)
+			return
+		}
+		bldr.Append(" OVER " + bldr.QuoteIdent(m.window.name))
+		return
+	}
+	if len(m.window.partitionBy) == 0 && len(m.window.orderBy) == 0 && m.window.frame == nil {
+		if m.window.err != nil {
+			bldr.SetError(m.window.err)
+			return
+		}
+		bldr.Append(" OVER ()")
+		return
+	}
+	bldr.Append(" OVER ( ")
+	m.window.serializeSpec(bldr)
+	bldr.Append(" )")
+}
+
+func (m *windowFunc) As(alias string) Column {
+	return &aliasColumn{
+		column: m,
+		alias:  alias,
+	}
+}
+
+func (m *windowFunc) column_name() string {
+	return m.fn.column_name()
+}
+
+func (m *windowFunc) config() ColumnConfig {
+	return m.fn.config()
+}
+
+func (m *windowFunc) acceptType(val interface{}) bool {
+	return m.fn.acceptType(val)
+}
+
+func (m *windowFunc) columns() []Column {
+	list := make([]Column, 0)
+	list = append(list, m.fn.columns()...)
+	if m.window != nil && len(m.window.name) == 0 {
+		list = append(list, m.window.columns()...)
+	}
+	return list
+}
+
+func (left *windowFunc) Eq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "=")
+}
+
+func (left *windowFunc) NotEq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "<>")
+}
+
+func (left *windowFunc) Gt(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, ">")
+}
+
+func (left *windowFunc) GtEq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, ">=")
+}
+
+func (left *windowFunc) Lt(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "<")
+}
+
+func (left *windowFunc) LtEq(right interface{}) Condition {
+	return newBinaryOperationCondition(left, right, "<=")
+}
+
+func (left *windowFunc) Like(right string) Condition {
+	return newBinaryOperationCondition(left, right, " LIKE ")
+}
+
+func (left *windowFunc) NotLike(right string) Condition {
+	return newBinaryOperationCondition(left, right, " NOT LIKE ")
+}
+
+func (left *windowFunc) Between(lower, higher interface{}) Condition {
+	return newBetweenCondition(left, lower, higher)
+}
+
+func (left *windowFunc) NotBetween(lower, higher interface{}) Condition {
+	return newNotBetweenCondition(left, lower, higher)
+}
+
+func (left *windowFunc) In(vals ...interface{}) Condition {
+	return newInCondition(left, vals...)
+}
+
+func (left *windowFunc) NotIn(vals ...interface{}) Condition {
+	return newNotInCondition(left, vals...)
+}
+
+func (left *windowFunc) IsNull() Condition {
+	return newNullCondition(left, false)
+}
+
+func (left *windowFunc) IsNotNull() Condition {
+	return newNullCondition(left, true)
+}
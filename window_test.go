@@ -0,0 +1,90 @@
+package sqlbuilder
+
+import (
+	"testing"
+)
+
+func TestWindowFunc(t *testing.T) {
+	table1 := NewTable(
+		"TABLE_A",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+		IntColumn("grp", nil),
+		IntColumn("value", nil),
+	)
+	table2 := NewTable(
+		"TABLE_B",
+		&TableOption{},
+		IntColumn("id", &ColumnOption{
+			PrimaryKey: true,
+		}),
+	)
+	rowNumber := Func("ROW_NUMBER").Over(NewWindow().PartitionBy(table1.C("grp")).OrderBy(true, table1.C("value")))
+	w := NewNamedWindow("w").PartitionBy(table1.C("grp")).OrderBy(false, table1.C("id"))
+
+	var cases = []statementTestCase{{
+		stmt:   Select(table1).Columns(table1.C("id"), rowNumber.As("rn")),
+		query:  `SELECT "TABLE_A"."id", ROW_NUMBER() OVER ( PARTITION BY "TABLE_A"."grp" ORDER BY "TABLE_A"."value" DESC ) AS "rn" FROM "TABLE_A";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Columns(table1.C("id"), Func("RANK").Over(NewWindow())).OrderBy(false, Func("RANK").Over(NewWindow())),
+		query:  `SELECT "TABLE_A"."id", RANK() OVER () FROM "TABLE_A" ORDER BY RANK() OVER () ASC;`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt: Select(table1).Columns(
+			table1.C("id"),
+			Func("SUM", table1.C("value")).Over(NewWindow().OrderBy(false, table1.C("id")).Rows(UnboundedPreceding, CurrentRow)).As("total"),
+			Func("AVG", table1.C("value")).Over(NewWindow().OrderBy(false, table1.C("id")).Range(Preceding(2), Following(2))).As("avg"),
+		),
+		query: `SELECT "TABLE_A"."id", ` +
+			`SUM("TABLE_A"."value") OVER ( ORDER BY "TABLE_A"."id" ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW ) AS "total", ` +
+			`AVG("TABLE_A"."value") OVER ( ORDER BY "TABLE_A"."id" ASC RANGE BETWEEN 2 PRECEDING AND 2 FOLLOWING ) AS "avg" FROM "TABLE_A";`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt: Select(table1).
+			Columns(table1.C("id"), Func("LAG", table1.C("value")).Over(w).As("prev"), rowNumber.As("rn")).
+			Window(w).
+			OrderBy(false, rowNumber.As("rn")),
+		query: `SELECT "TABLE_A"."id", LAG("TABLE_A"."value") OVER "w" AS "prev", ` +
+			`ROW_NUMBER() OVER ( PARTITION BY "TABLE_A"."grp" ORDER BY "TABLE_A"."value" DESC ) AS "rn" FROM "TABLE_A" WINDOW "w" AS ( PARTITION BY "TABLE_A"."grp" ORDER BY "TABLE_A"."id" ASC ) ORDER BY "rn" ASC;`,
+		args:   []interface{}{},
+		errmsg: "",
+	}, {
+		stmt:   Select(table1).Columns(Func("LAG", table1.C("value")).Over(w)),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: window w is not defined.",
+	}, {
+		stmt:   Select(table1).Window(w, w),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: window w was duplicated.",
+	}, {
+		stmt:   Select(table1).Window(NewWindow()),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: window in WINDOW clause needs name.",
+	}, {
+		stmt:   Select(table1).Columns(Func("RANK").Over(NewWindow().OrderBy(false, table2.C("id")))),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: column not found in FROM.",
+	}, {
+		stmt:   Select(table1).Columns(Func("SUM", table1.C("value")).Over(NewWindow().Rows(CurrentRow, UnboundedPreceding))),
+		query:  ``,
+		args:   []interface{}{},
+		errmsg: "sqlbuilder: invalid window frame.",
+	}}
+
+	for num, c := range cases {
+		mes, args, ok := c.Run()
+		if !ok {
+			t.Errorf(mes+" (case no.%d)", append(args, num)...)
+		}
+	}
+}